@@ -0,0 +1,96 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+// Border styles
+const (
+	SingleBorder  = formatter.SingleBorder
+	DoubleBorder  = formatter.DoubleBorder
+	RoundedBorder = formatter.RoundedBorder
+	HeavyBorder   = formatter.HeavyBorder
+	ASCIIBorder   = formatter.ASCIIBorder
+)
+
+// BoxOptions are the options used to render a box. Border is one of
+// SingleBorder (the default), DoubleBorder, RoundedBorder, HeavyBorder or
+// ASCIIBorder. Padding is the number of spaces between the vertical borders and
+// the body and defaults to 1, or 0 if NoPadding is true. Color and TitleColor
+// are the colors of the border and title. Width is the maximum width of the box
+// and defaults to the width of the Printer.
+type BoxOptions struct {
+	Border     string
+	Padding    int
+	NoPadding  bool
+	Color      string
+	TitleColor string
+	Width      int
+}
+
+// Box prints the passed body inside a bordered panel with the passed title set
+// in the top border. The box is sized to fit the body, up to the width of the
+// Printer, and body lines that don't fit are wrapped. If opts is nil the
+// default BoxOptions are used.
+func Box(title, body string, opts *BoxOptions) {
+	singleton.Box(title, body, opts)
+}
+
+// Box prints the passed body inside a bordered panel with the passed title set
+// in the top border. The box is sized to fit the body, up to the width of the
+// Printer, and body lines that don't fit are wrapped. If opts is nil the
+// default BoxOptions are used.
+func (p *Printer) Box(title, body string, opts *BoxOptions) {
+	for _, line := range p.formatter.Box(title, body, p.formatterBoxOptions(opts)) {
+		fmt.Fprintln(p.OutWriter, line)
+	}
+}
+
+// Heading prints the passed text as a section heading. Level 1 headings are
+// underlined with a double line, level 2 headings with a single line, and
+// headings of any other level are ruled out to the width of the Printer.
+func Heading(level int, text string) {
+	singleton.Heading(level, text)
+}
+
+// Heading prints the passed text as a section heading. Level 1 headings are
+// underlined with a double line, level 2 headings with a single line, and
+// headings of any other level are ruled out to the width of the Printer.
+func (p *Printer) Heading(level int, text string) {
+	for _, line := range p.formatter.Heading(level, text, p.Width()) {
+		fmt.Fprintln(p.OutWriter, line)
+	}
+}
+
+// Rule prints a horizontal separator the width of the Printer
+func Rule() {
+	singleton.Rule()
+}
+
+// Rule prints a horizontal separator the width of the Printer
+func (p *Printer) Rule() {
+	fmt.Fprintln(p.OutWriter, p.formatter.Rule(p.Width()))
+}
+
+func (p *Printer) formatterBoxOptions(opts *BoxOptions) *formatter.BoxOptions {
+	fOpts := &formatter.BoxOptions{Border: SingleBorder, Padding: 1, Width: p.Width()}
+	if opts == nil {
+		return fOpts
+	}
+	if opts.Border != "" {
+		fOpts.Border = opts.Border
+	}
+	if opts.NoPadding {
+		fOpts.Padding = 0
+	} else if opts.Padding > 0 {
+		fOpts.Padding = opts.Padding
+	}
+	if opts.Width > 0 {
+		fOpts.Width = opts.Width
+	}
+	fOpts.Color = opts.Color
+	fOpts.TitleColor = opts.TitleColor
+	return fOpts
+}
@@ -0,0 +1,44 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+func (suite *PrinterSuite) TestBox() {
+	opts := &formatter.BoxOptions{Border: RoundedBorder, Padding: 2, Color: Blue, Width: 80}
+	suite.Formatter.On("Box", "title", "body", opts).Return([]string{"top", "body", "bottom"})
+	Box("title", "body", &BoxOptions{Border: RoundedBorder, Padding: 2, Color: Blue})
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("top"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("body"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("bottom"))
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 3)
+}
+
+func (suite *PrinterSuite) TestBoxWithDefaultOptions() {
+	opts := &formatter.BoxOptions{Border: SingleBorder, Padding: 1, Width: 80}
+	suite.Formatter.On("Box", "", "body", opts).Return([]string{"boxed"})
+	Box("", "body", nil)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("boxed"))
+}
+
+func (suite *PrinterSuite) TestBoxWithoutPadding() {
+	opts := &formatter.BoxOptions{Border: SingleBorder, Padding: 0, Width: 80}
+	suite.Formatter.On("Box", "", "body", opts).Return([]string{"boxed"})
+	Box("", "body", &BoxOptions{NoPadding: true})
+	suite.Formatter.AssertCalled(suite.T(), "Box", "", "body", opts)
+}
+
+func (suite *PrinterSuite) TestHeading() {
+	suite.Formatter.On("Heading", 2, "Section", 80).Return([]string{"Section", "-------"})
+	Heading(2, "Section")
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("Section"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("-------"))
+}
+
+func (suite *PrinterSuite) TestRule() {
+	suite.Formatter.On("Rule", 80).Return("----")
+	Rule()
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("----"))
+}
@@ -0,0 +1,76 @@
+package printer
+
+import "github.com/tomguerney/printer/internal/formatter"
+
+// minSideBySideWidth is the narrowest width a diff is shown side by side at
+const minSideBySideWidth = 100
+
+// DiffOptions are the options used to print a diff. Context is the number of
+// unchanged lines shown around each change, and defaults to 3. A negative
+// Context shows no unchanged lines. If SideBySide is true the old and new text
+// are shown in two columns, as long as the Printer is at least 100 columns wide.
+// If Words is true the words that differ within changed lines are highlighted.
+// OldName and NewName are shown in the header of a text diff, and default to
+// "old" and "new".
+type DiffOptions struct {
+	Context    int
+	SideBySide bool
+	Words      bool
+	OldName    string
+	NewName    string
+}
+
+// Diff prints the differences between the old and new text as a colored
+// unified diff, found with Myers' diff algorithm. Deleted lines are red and
+// prefixed with "-", and inserted lines are green and prefixed with "+". It
+// prints nothing if the texts are the same. If opts is nil the default
+// DiffOptions are used.
+func Diff(oldText, newText string, opts *DiffOptions) {
+	singleton.Diff(oldText, newText, opts)
+}
+
+// Diff prints the differences between the old and new text as a colored
+// unified diff, found with Myers' diff algorithm. Deleted lines are red and
+// prefixed with "-", and inserted lines are green and prefixed with "+". It
+// prints nothing if the texts are the same. If opts is nil the default
+// DiffOptions are used.
+func (p *Printer) Diff(oldText, newText string, opts *DiffOptions) {
+	p.printLines(p.formatter.Diff(oldText, newText, p.formatterDiffOptions(opts)))
+}
+
+// DiffMaps prints the keys added to, removed from and changed between the old
+// and new maps, sorted by key. Added keys are green and prefixed with "+",
+// removed keys are red and prefixed with "-", and changed keys are prefixed
+// with "~" and show the old and new value. It prints nothing if the maps are
+// the same. Only the Words option applies to maps.
+func DiffMaps(oldMap, newMap map[string]string, opts *DiffOptions) {
+	singleton.DiffMaps(oldMap, newMap, opts)
+}
+
+// DiffMaps prints the keys added to, removed from and changed between the old
+// and new maps, sorted by key. Added keys are green and prefixed with "+",
+// removed keys are red and prefixed with "-", and changed keys are prefixed
+// with "~" and show the old and new value. It prints nothing if the maps are
+// the same. Only the Words option applies to maps.
+func (p *Printer) DiffMaps(oldMap, newMap map[string]string, opts *DiffOptions) {
+	p.printLines(p.formatter.DiffMaps(oldMap, newMap, p.formatterDiffOptions(opts)))
+}
+
+func (p *Printer) formatterDiffOptions(opts *DiffOptions) *formatter.DiffOptions {
+	fOpts := &formatter.DiffOptions{Context: 3, Width: p.Width(), OldName: "old", NewName: "new"}
+	if opts == nil {
+		return fOpts
+	}
+	if opts.Context != 0 {
+		fOpts.Context = opts.Context
+	}
+	if opts.OldName != "" {
+		fOpts.OldName = opts.OldName
+	}
+	if opts.NewName != "" {
+		fOpts.NewName = opts.NewName
+	}
+	fOpts.SideBySide = opts.SideBySide && fOpts.Width >= minSideBySideWidth
+	fOpts.Words = opts.Words
+	return fOpts
+}
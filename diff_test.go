@@ -0,0 +1,38 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+func (suite *PrinterSuite) TestDiff() {
+	opts := &formatter.DiffOptions{Context: 3, Width: 80, OldName: "old", NewName: "new"}
+	suite.Formatter.On("Diff", "a\n", "b\n", opts).Return([]string{"-a", "+b"})
+	Diff("a\n", "b\n", nil)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("-a"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("+b"))
+}
+
+func (suite *PrinterSuite) TestDiffWithOptions() {
+	SetWidth(120)
+	opts := &formatter.DiffOptions{Context: -1, Width: 120, SideBySide: true, Words: true, OldName: "before", NewName: "new"}
+	suite.Formatter.On("Diff", "a\n", "b\n", opts).Return([]string{})
+	Diff("a\n", "b\n", &DiffOptions{Context: -1, SideBySide: true, Words: true, OldName: "before"})
+	suite.Formatter.AssertExpectations(suite.T())
+}
+
+func (suite *PrinterSuite) TestDiffSideBySideWhenTooNarrow() {
+	opts := &formatter.DiffOptions{Context: 3, Width: 80, OldName: "old", NewName: "new"}
+	suite.Formatter.On("Diff", "a\n", "b\n", opts).Return([]string{})
+	Diff("a\n", "b\n", &DiffOptions{SideBySide: true})
+	suite.Formatter.AssertExpectations(suite.T())
+}
+
+func (suite *PrinterSuite) TestDiffMaps() {
+	oldMap := map[string]string{"a": "1"}
+	newMap := map[string]string{"a": "2"}
+	suite.Formatter.On("DiffMaps", oldMap, newMap, &formatter.DiffOptions{Context: 3, Width: 80, OldName: "old", NewName: "new", Words: true}).Return([]string{"~ a: 1 → 2"})
+	DiffMaps(oldMap, newMap, &DiffOptions{Words: true})
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("~ a: 1 → 2"))
+}
@@ -0,0 +1,245 @@
+package printer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// exit exits the program, and is replaced in tests
+var exit = os.Exit
+
+// SetVerbose sets whether verbose output, such as the stack traces of errors,
+// is printed
+func SetVerbose(verbose bool) {
+	singleton.SetVerbose(verbose)
+}
+
+// SetVerbose sets whether verbose output, such as the stack traces of errors,
+// is printed
+func (p *Printer) SetVerbose(verbose bool) {
+	p.verbose = verbose
+}
+
+// Error prints the passed error to the ErrWriter, prefixed with "Error: ".
+//
+// The errors it wraps, found with errors.Unwrap or an `Unwrap() []error`
+// method such as that of errors.Join, are printed beneath it as an indented
+// "caused by" list. If any error in the chain has a `Hint() string` method, its
+// hint is printed after the chain, and if any has a `Suggestions() []string`
+// method, its suggestions are listed. If verbose output is on, the stack trace
+// of the deepest error with a `StackTrace()` method, such as those created by
+// "github.com/pkg/errors", is printed last.
+func Error(err error) {
+	singleton.Error(err)
+}
+
+// Error prints the passed error to the ErrWriter, prefixed with "Error: ".
+//
+// The errors it wraps, found with errors.Unwrap or an `Unwrap() []error`
+// method such as that of errors.Join, are printed beneath it as an indented
+// "caused by" list. If any error in the chain has a `Hint() string` method, its
+// hint is printed after the chain, and if any has a `Suggestions() []string`
+// method, its suggestions are listed. If verbose output is on, the stack trace
+// of the deepest error with a `StackTrace()` method, such as those created by
+// "github.com/pkg/errors", is printed last.
+func (p *Printer) Error(err error) {
+	if err == nil {
+		return
+	}
+	lines := []string{}
+	if message, causes := explain(err); message == "" {
+		for _, cause := range causes {
+			lines = append(lines, p.errorLines(cause, "Error:", Red, 0)...)
+		}
+	} else {
+		lines = append(lines, p.errorLines(err, "Error:", Red, 0)...)
+	}
+	chain := walk(err)
+	for _, hint := range hints(chain) {
+		lines = append(lines, p.colorOr("Hint:", Cyan)+" "+hint)
+	}
+	if suggestions := suggestions(chain); len(suggestions) > 0 {
+		lines = append(lines, "Did you mean:")
+		for _, suggestion := range suggestions {
+			lines = append(lines, "  "+suggestion)
+		}
+	}
+	if p.verbose {
+		if trace := stackTrace(chain); trace != "" {
+			lines = append(lines, "", "Stack trace:")
+			lines = append(lines, strings.Split(strings.Trim(trace, "\n"), "\n")...)
+		}
+	}
+	for _, line := range lines {
+		fmt.Fprintln(p.ErrWriter, line)
+	}
+}
+
+// Fatal prints the passed error as per Error and exits the program. The exit
+// code is that of the first error in the error's chain with an `ExitCode() int`
+// method, or 1 if none has one.
+func Fatal(err error) {
+	singleton.Fatal(err)
+}
+
+// Fatal prints the passed error as per Error and exits the program. The exit
+// code is that of the first error in the error's chain with an `ExitCode() int`
+// method, or 1 if none has one.
+func (p *Printer) Fatal(err error) {
+	p.Error(err)
+	exit(exitCode(err))
+}
+
+// errorLines renders an error and the chain of errors it wraps. The first line
+// is prefixed with the label in the passed color, and each cause is indented
+// beneath it.
+func (p *Printer) errorLines(err error, label, color string, depth int) []string {
+	message, causes := explain(err)
+	indent := strings.Repeat("  ", depth)
+	prefix := label
+	if color != "" {
+		prefix = p.colorOr(label, color)
+	}
+	hanging := indent + strings.Repeat(" ", len(label)+1)
+	lines := []string{indent + prefix + " " + indentLines(message, hanging)}
+	if len(causes) == 1 {
+		if cause, _ := explain(causes[0]); cause == "" {
+			causes = unwrapAll(causes[0])
+		}
+	}
+	if len(causes) == 1 {
+		return append(lines, p.errorLines(causes[0], "caused by:", Yellow, depth+1)...)
+	}
+	if len(causes) > 1 {
+		lines = append(lines, indent+"  "+p.colorOr("caused by:", Yellow))
+		for _, cause := range causes {
+			lines = append(lines, p.errorLines(cause, "-", "", depth+2)...)
+		}
+	}
+	return lines
+}
+
+// explain returns an error's own message, without the messages of the errors
+// it wraps, and the errors it wraps. The own message of an error that only
+// joins other errors is empty.
+func explain(err error) (string, []error) {
+	message := err.Error()
+	causes := unwrapAll(err)
+	switch len(causes) {
+	case 0:
+		return message, nil
+	case 1:
+		own := strings.TrimSuffix(message, causes[0].Error())
+		if own == message {
+			return message, causes
+		}
+		return strings.TrimRight(own, ": "), causes
+	default:
+		joined := make([]string, len(causes))
+		for i, cause := range causes {
+			joined[i] = cause.Error()
+		}
+		if message == strings.Join(joined, "\n") {
+			return "", causes
+		}
+		return message, causes
+	}
+}
+
+// unwrapAll returns the errors an error wraps
+func unwrapAll(err error) []error {
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		causes := []error{}
+		for _, cause := range multi.Unwrap() {
+			if cause != nil {
+				causes = append(causes, cause)
+			}
+		}
+		return causes
+	}
+	if cause := errors.Unwrap(err); cause != nil {
+		return []error{cause}
+	}
+	return nil
+}
+
+// walk returns every error in an error's chain, depth first
+func walk(err error) []error {
+	chain := []error{err}
+	for _, cause := range unwrapAll(err) {
+		chain = append(chain, walk(cause)...)
+	}
+	return chain
+}
+
+func hints(chain []error) []string {
+	hints := []string{}
+	seen := map[string]bool{}
+	for _, err := range chain {
+		if h, ok := err.(interface{ Hint() string }); ok {
+			if hint := h.Hint(); hint != "" && !seen[hint] {
+				seen[hint] = true
+				hints = append(hints, hint)
+			}
+		}
+	}
+	return hints
+}
+
+func suggestions(chain []error) []string {
+	suggestions := []string{}
+	seen := map[string]bool{}
+	for _, err := range chain {
+		if s, ok := err.(interface{ Suggestions() []string }); ok {
+			for _, suggestion := range s.Suggestions() {
+				if !seen[suggestion] {
+					seen[suggestion] = true
+					suggestions = append(suggestions, suggestion)
+				}
+			}
+		}
+	}
+	return suggestions
+}
+
+// stackTrace returns the stack trace of the deepest error in the chain with a
+// StackTrace method, formatted with "%+v"
+func stackTrace(chain []error) string {
+	for i := len(chain) - 1; i >= 0; i-- {
+		method := reflect.ValueOf(chain[i]).MethodByName("StackTrace")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		trace := method.Call(nil)[0].Interface()
+		if b, ok := trace.([]byte); ok {
+			return string(b)
+		}
+		return fmt.Sprintf("%+v", trace)
+	}
+	return ""
+}
+
+func exitCode(err error) int {
+	for _, e := range walk(err) {
+		if c, ok := e.(interface{ ExitCode() int }); ok {
+			return c.ExitCode()
+		}
+	}
+	return 1
+}
+
+// colorOr colors text, or returns it uncolored if the color isn't available
+func (p *Printer) colorOr(text, color string) string {
+	if colored, ok := p.stenciller.Color(text, color); ok {
+		return colored
+	}
+	return text
+}
+
+// indentLines indents every line of text but the first
+func indentLines(text, indent string) string {
+	return strings.Replace(text, "\n", "\n"+indent, -1)
+}
@@ -0,0 +1,138 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type hintError struct {
+	error
+	hint        string
+	suggestions []string
+	code        int
+}
+
+func (e *hintError) Unwrap() error         { return e.error }
+func (e *hintError) Hint() string          { return e.hint }
+func (e *hintError) Suggestions() []string { return e.suggestions }
+func (e *hintError) ExitCode() int         { return e.code }
+
+type joinError []error
+
+func (e joinError) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (e joinError) Unwrap() []error { return e }
+
+type stackError struct{ error }
+
+func (e *stackError) StackTrace() string { return "main.main\n\tmain.go:10\n" }
+
+func (suite *PrinterSuite) errOutput(err error) string {
+	out := new(bytes.Buffer)
+	singleton.ErrWriter = out
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	Error(err)
+	return out.String()
+}
+
+func (suite *PrinterSuite) TestError() {
+	suite.Equal("Error: failed\n", suite.errOutput(errors.New("failed")))
+}
+
+func (suite *PrinterSuite) TestErrorWithChain() {
+	cause := errors.New("permission denied")
+	err := fmt.Errorf("deploy failed: %w", fmt.Errorf("open config.yaml: %w", cause))
+	expected := strings.Join([]string{
+		"Error: deploy failed",
+		"  caused by: open config.yaml",
+		"    caused by: permission denied",
+		"",
+	}, "\n")
+	suite.Equal(expected, suite.errOutput(err))
+}
+
+func (suite *PrinterSuite) TestErrorWithJoinedErrors() {
+	joined := joinError{errors.New("name is required"), fmt.Errorf("invalid port: %w", errors.New("not a number"))}
+	err := fmt.Errorf("validation failed: %w", joined)
+	expected := strings.Join([]string{
+		"Error: validation failed",
+		"  caused by:",
+		"    - name is required",
+		"    - invalid port",
+		"      caused by: not a number",
+		"",
+	}, "\n")
+	suite.Equal(expected, suite.errOutput(err))
+}
+
+func (suite *PrinterSuite) TestErrorWithTopLevelJoinedErrors() {
+	err := joinError{errors.New("first"), errors.New("second\nline")}
+	expected := "Error: first\nError: second\n       line\n"
+	suite.Equal(expected, suite.errOutput(err))
+}
+
+func (suite *PrinterSuite) TestErrorWithHints() {
+	err := fmt.Errorf("unable to run: %w", &hintError{
+		error:       errors.New("unknown command \"stauts\""),
+		hint:        "Run 'tool help' for a list of commands",
+		suggestions: []string{"status"},
+	})
+	expected := strings.Join([]string{
+		"Error: unable to run",
+		"  caused by: unknown command \"stauts\"",
+		"Hint: Run 'tool help' for a list of commands",
+		"Did you mean:",
+		"  status",
+		"",
+	}, "\n")
+	suite.Equal(expected, suite.errOutput(err))
+}
+
+func (suite *PrinterSuite) TestErrorWithStackTrace() {
+	err := fmt.Errorf("failed: %w", &stackError{errors.New("boom")})
+	suite.NotContains(suite.errOutput(err), "Stack trace")
+	SetVerbose(true)
+	expected := strings.Join([]string{
+		"Error: failed",
+		"  caused by: boom",
+		"",
+		"Stack trace:",
+		"main.main",
+		"\tmain.go:10",
+		"",
+	}, "\n")
+	suite.Equal(expected, suite.errOutput(err))
+}
+
+func (suite *PrinterSuite) TestErrorWithNil() {
+	suite.Equal("", suite.errOutput(nil))
+}
+
+func (suite *PrinterSuite) TestFatal() {
+	code := 0
+	defer func(original func(int)) { exit = original }(exit)
+	exit = func(c int) { code = c }
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	singleton.ErrWriter = new(bytes.Buffer)
+	Fatal(fmt.Errorf("wrapped: %w", &hintError{error: errors.New("usage"), code: 2}))
+	suite.Equal(2, code)
+	Fatal(errors.New("failed"))
+	suite.Equal(1, code)
+}
+
+func (suite *PrinterSuite) TestSetErrWriter() {
+	out := new(bytes.Buffer)
+	SetErrWriter(out)
+	suite.Equal(out, singleton.ErrWriter)
+	suite.Equal(suite.OutWriter, singleton.OutWriter)
+}
@@ -0,0 +1,52 @@
+package printer
+
+import (
+	"io"
+
+	"github.com/tomguerney/printer/internal/exporter"
+)
+
+// Export formats
+const (
+	CSV      = exporter.CSV
+	TSV      = exporter.TSV
+	Markdown = exporter.Markdown
+	HTML     = exporter.HTML
+)
+
+// ExportTable takes the ID of a Table Stencil, a slice of "row" maps with string
+// key/values, an export format and an io.Writer. It returns an error if it
+// can't find a Stencil with the passed ID or the format is unknown. It applies
+// the Table Stencil's column order and headers to the rows, as UseTableStencil
+// does, and writes the result to the io.Writer in the export format.
+//
+// The export formats are CSV (as per RFC 4180), TSV, Markdown (as a GitHub
+// Flavored Markdown table) and HTML (as a standalone document). Colors aren't
+// written to CSV, TSV or Markdown, and are written to HTML as CSS classes. CSV
+// and TSV cells that a spreadsheet would interpret as a formula are escaped.
+func ExportTable(id string, rows []map[string]string, format string, w io.Writer) error {
+	return singleton.ExportTable(id, rows, format, w)
+}
+
+// ExportTable takes the ID of a Table Stencil, a slice of "row" maps with string
+// key/values, an export format and an io.Writer. It returns an error if it
+// can't find a Stencil with the passed ID or the format is unknown. It applies
+// the Table Stencil's column order and headers to the rows, as UseTableStencil
+// does, and writes the result to the io.Writer in the export format.
+//
+// The export formats are CSV (as per RFC 4180), TSV, Markdown (as a GitHub
+// Flavored Markdown table) and HTML (as a standalone document). Colors aren't
+// written to CSV, TSV or Markdown, and are written to HTML as CSS classes. CSV
+// and TSV cells that a spreadsheet would interpret as a formula are escaped.
+func (p *Printer) ExportTable(id string, rows []map[string]string, format string, w io.Writer) error {
+	table, err := p.stenciller.TableData(id, rows)
+	if err != nil {
+		return err
+	}
+	return exporter.Export(&exporter.Table{
+		Headers: table.Headers,
+		Columns: table.Columns,
+		Rows:    table.Rows,
+		Colors:  table.Colors,
+	}, format, w)
+}
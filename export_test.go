@@ -0,0 +1,34 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestExportTable() {
+	id := "test id"
+	rows := []map[string]string{{"key": "value"}}
+	table := &stenciller.Table{
+		Headers: []string{"Key"},
+		Columns: []string{"key"},
+		Rows:    [][]string{{"value"}},
+		Colors:  []string{Red},
+	}
+	suite.Stenciller.On("TableData", id, rows).Return(table, nil)
+	out := new(bytes.Buffer)
+	err := ExportTable(id, rows, CSV, out)
+	suite.NoError(err)
+	suite.Equal("Key\r\nvalue\r\n", out.String())
+}
+
+func (suite *PrinterSuite) TestExportTableWithError() {
+	id := "test id"
+	rows := []map[string]string{{"key": "value"}}
+	suite.Stenciller.On("TableData", id, rows).Return((*stenciller.Table)(nil), errors.New("error"))
+	out := new(bytes.Buffer)
+	err := ExportTable(id, rows, CSV, out)
+	suite.Error(err)
+	suite.Empty(out.String())
+}
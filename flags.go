@@ -0,0 +1,202 @@
+package printer
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// setting is an output option that can be set by a flag or an environment
+// variable
+type setting struct {
+	name    string
+	usage   string
+	boolean bool
+	set     func(p *Printer, value string) error
+}
+
+var settings = []*setting{
+	{
+		name:  "output",
+		usage: "output format of tables: table, csv, tsv, markdown or html",
+		set: func(p *Printer, value string) error {
+			if err := oneOf(value, TableOutput, CSV, TSV, Markdown, HTML); err != nil {
+				return err
+			}
+			p.SetOutputFormat(value)
+			return nil
+		},
+	},
+	{
+		name:  "color",
+		usage: "when to color output: auto, always or never",
+		set: func(p *Printer, value string) error {
+			if err := oneOf(value, ColorAuto, ColorAlways, ColorNever); err != nil {
+				return err
+			}
+			p.SetColorMode(value)
+			return nil
+		},
+	},
+	{
+		name:  "pager",
+		usage: "when to page long output: auto, always or never",
+		set: func(p *Printer, value string) error {
+			if err := oneOf(value, PagerAuto, PagerAlways, PagerNever); err != nil {
+				return err
+			}
+			p.SetPagerMode(value)
+			return nil
+		},
+	},
+	{
+		name:    "no-pager",
+		usage:   "never page long output",
+		boolean: true,
+		set: func(p *Printer, value string) error {
+			noPager, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			if noPager {
+				p.SetPagerMode(PagerNever)
+			}
+			return nil
+		},
+	},
+	{
+		name:  "width",
+		usage: "width of output in columns, or 0 for the width of the terminal",
+		set: func(p *Printer, value string) error {
+			width, err := strconv.Atoi(value)
+			if err != nil || width < 0 {
+				return fmt.Errorf("%q isn't a width in columns", value)
+			}
+			p.SetWidth(width)
+			return nil
+		},
+	},
+	{
+		name:    "quiet",
+		usage:   "only print warnings and errors",
+		boolean: true,
+		set: func(p *Printer, value string) error {
+			quiet, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			p.SetQuiet(quiet)
+			return nil
+		},
+	},
+	{
+		name:    "verbose",
+		usage:   "print debug output and the stack traces of errors",
+		boolean: true,
+		set: func(p *Printer, value string) error {
+			verbose, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			p.SetVerbose(verbose)
+			return nil
+		},
+	},
+	{
+		name:  "table-style",
+		usage: "style of tables: plain, compact or striped",
+		set: func(p *Printer, value string) error {
+			if err := oneOf(value, TablePlain, TableCompact, TableStriped); err != nil {
+				return err
+			}
+			p.SetTableStyle(value)
+			return nil
+		},
+	},
+}
+
+// RegisterFlags adds the standard output flags to the passed FlagSet. When the
+// FlagSet is parsed, each flag passed configures the Printer:
+//
+//	--output        the output format of tables, as per SetOutputFormat
+//	--color         the color mode, as per SetColorMode
+//	--pager         the pager mode, as per SetPagerMode
+//	--no-pager      sets the pager mode to PagerNever
+//	--width         the width of output, as per SetWidth
+//	--quiet         suppresses informational output, as per SetQuiet
+//	--verbose       turns on verbose output, as per SetVerbose
+//	--table-style   the style of tables, as per SetTableStyle
+//
+// Parsing fails if a flag has an invalid value.
+func RegisterFlags(fs *flag.FlagSet) {
+	singleton.RegisterFlags(fs)
+}
+
+// RegisterFlags adds the standard output flags to the passed FlagSet. When the
+// FlagSet is parsed, each flag passed configures the Printer:
+//
+//	--output        the output format of tables, as per SetOutputFormat
+//	--color         the color mode, as per SetColorMode
+//	--pager         the pager mode, as per SetPagerMode
+//	--no-pager      sets the pager mode to PagerNever
+//	--width         the width of output, as per SetWidth
+//	--quiet         suppresses informational output, as per SetQuiet
+//	--verbose       turns on verbose output, as per SetVerbose
+//	--table-style   the style of tables, as per SetTableStyle
+//
+// Parsing fails if a flag has an invalid value.
+func (p *Printer) RegisterFlags(fs *flag.FlagSet) {
+	for _, s := range settings {
+		set := s.set
+		apply := func(value string) error { return set(p, value) }
+		if s.boolean {
+			fs.BoolFunc(s.name, s.usage, apply)
+		} else {
+			fs.Func(s.name, s.usage, apply)
+		}
+	}
+}
+
+// LoadEnv configures the Printer from the environment variables equivalent to
+// the flags of RegisterFlags, named with a "PRINTER_" prefix in upper snake
+// case, e.g. PRINTER_OUTPUT, PRINTER_NO_PAGER and PRINTER_TABLE_STYLE. Empty
+// variables are ignored. It returns an error if a variable has an invalid
+// value. Call it before parsing flags so that flags take precedence.
+func LoadEnv() error {
+	return singleton.LoadEnv()
+}
+
+// LoadEnv configures the Printer from the environment variables equivalent to
+// the flags of RegisterFlags, named with a "PRINTER_" prefix in upper snake
+// case, e.g. PRINTER_OUTPUT, PRINTER_NO_PAGER and PRINTER_TABLE_STYLE. Empty
+// variables are ignored. It returns an error if a variable has an invalid
+// value. Call it before parsing flags so that flags take precedence.
+func (p *Printer) LoadEnv() error {
+	for _, s := range settings {
+		name := "PRINTER_" + strings.ToUpper(strings.Replace(s.name, "-", "_", -1))
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if err := s.set(p, value); err != nil {
+			return fmt.Errorf("Invalid value for %v: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// oneOf returns an error if the passed value isn't one of the passed options
+func oneOf(value string, options ...string) error {
+	for _, option := range options {
+		if value == option {
+			return nil
+		}
+	}
+	quoted := make([]string, len(options))
+	for i, option := range options {
+		quoted[i] = strconv.Quote(option)
+	}
+	return fmt.Errorf("%q isn't one of %v", value, strings.Join(quoted, ", "))
+}
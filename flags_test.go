@@ -0,0 +1,78 @@
+package printer
+
+import (
+	"flag"
+	"io/ioutil"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+func (suite *PrinterSuite) TestRegisterFlags() {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	suite.Formatter.On("SetTabwriterOptions", mock.Anything).Return()
+	suite.Formatter.On("TabwriterOptions").Return(&formatter.TabwriterOptions{
+		Minwidth: 3,
+		Tabwidth: 8,
+		Padding:  4,
+		Padchar:  '.',
+		Divchar:  '=',
+	})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+	err := fs.Parse([]string{
+		"--output", "csv",
+		"--color", "always",
+		"--no-pager",
+		"--width", "100",
+		"--quiet",
+		"--verbose",
+		"--table-style", "compact",
+		"remaining",
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"remaining"}, fs.Args())
+	suite.Equal(CSV, singleton.outputFormat)
+	suite.False(color.NoColor)
+	suite.Equal(PagerNever, singleton.pagerMode)
+	suite.Equal(100, singleton.width)
+	suite.True(singleton.quiet)
+	suite.True(singleton.verbose)
+	suite.Equal(TableCompact, singleton.tableStyle)
+	suite.Formatter.AssertCalled(suite.T(), "SetTabwriterOptions", &formatter.TabwriterOptions{
+		Minwidth: 3,
+		Tabwidth: 8,
+		Padding:  2,
+		Padchar:  '.',
+		Divchar:  '=',
+	})
+}
+
+func (suite *PrinterSuite) TestRegisterFlagsWithInvalidValue() {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	RegisterFlags(fs)
+	err := fs.Parse([]string{"--output", "xml"})
+	suite.EqualError(err, `invalid value "xml" for flag -output: "xml" isn't one of "table", "csv", "tsv", "markdown", "html"`)
+	err = fs.Parse([]string{"--width", "-1"})
+	suite.EqualError(err, `invalid value "-1" for flag -width: "-1" isn't a width in columns`)
+}
+
+func (suite *PrinterSuite) TestLoadEnv() {
+	suite.T().Setenv("PRINTER_OUTPUT", "markdown")
+	suite.T().Setenv("PRINTER_PAGER", "always")
+	suite.T().Setenv("PRINTER_QUIET", "true")
+	suite.T().Setenv("PRINTER_WIDTH", "")
+	suite.NoError(LoadEnv())
+	suite.Equal(Markdown, singleton.outputFormat)
+	suite.Equal(PagerAlways, singleton.pagerMode)
+	suite.True(singleton.quiet)
+	suite.Equal(80, singleton.width)
+}
+
+func (suite *PrinterSuite) TestLoadEnvWithInvalidValue() {
+	suite.T().Setenv("PRINTER_VERBOSE", "sometimes")
+	err := LoadEnv()
+	suite.EqualError(err, `Invalid value for PRINTER_VERBOSE: strconv.ParseBool: parsing "sometimes": invalid syntax`)
+}
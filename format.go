@@ -0,0 +1,197 @@
+package printer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tomguerney/printer/internal/humanize"
+)
+
+// Value formats
+const (
+	BytesFormat        = humanize.Bytes
+	DurationFormat     = humanize.Duration
+	TimeFormat         = humanize.Time
+	RelativeTimeFormat = humanize.RelativeTime
+	PercentFormat      = humanize.Percent
+	ThousandsFormat    = humanize.Thousands
+	BoolFormat         = humanize.Bool
+)
+
+// ValueFormat formats the data value of a Stencil key for humans. Type is one
+// of BytesFormat, DurationFormat, TimeFormat, RelativeTimeFormat,
+// PercentFormat, ThousandsFormat or BoolFormat, or empty for a format that only
+// replaces empty values with the Empty placeholder.
+//
+// Byte values are formatted in IEC units (KiB, MiB...) unless SI is true.
+// Durations are a Go duration such as "90m" or a number of seconds. Times are
+// formatted with Layout in Location, which default to the locale's date layout
+// and the local time zone, or relative to now (e.g. "3 minutes ago") with
+// RelativeTimeFormat. Percentages are formatted with Precision decimal places,
+// and thousands are grouped with Separator, which defaults to the locale's
+// group separator. Booleans are formatted as True or False, which default to
+// "✓" and "✗". Numbers are formatted with the locale's decimal separator, and
+// values that can't be parsed as the format's type are left as they are.
+type ValueFormat struct {
+	Type      string
+	SI        bool
+	Layout    string
+	Location  *time.Location
+	Precision int
+	Separator string
+	True      string
+	False     string
+	Empty     string
+}
+
+// FormatBytes formats a number of bytes in IEC units (e.g. "1.5 KiB"), or SI
+// units (e.g. "1.5 kB") if si is true
+func FormatBytes(n int64, si bool) string {
+	return singleton.FormatBytes(n, si)
+}
+
+// FormatBytes formats a number of bytes in IEC units (e.g. "1.5 KiB"), or SI
+// units (e.g. "1.5 kB") if si is true
+func (p *Printer) FormatBytes(n int64, si bool) string {
+	return p.loc().Decimals(humanize.FormatBytes(float64(n), si))
+}
+
+// FormatDuration formats a duration in its two largest units, e.g. "3d4h" or
+// "2m30s"
+func FormatDuration(d time.Duration) string {
+	return singleton.FormatDuration(d)
+}
+
+// FormatDuration formats a duration in its two largest units, e.g. "3d4h" or
+// "2m30s"
+func (p *Printer) FormatDuration(d time.Duration) string {
+	return humanize.FormatDuration(d)
+}
+
+// FormatTime formats a time with the passed layout in the passed location,
+// which default to the locale's date layout and the local time zone if empty or
+// nil
+func FormatTime(t time.Time, layout string, loc *time.Location) string {
+	return singleton.FormatTime(t, layout, loc)
+}
+
+// FormatTime formats a time with the passed layout in the passed location,
+// which default to the locale's date layout and the local time zone if empty or
+// nil
+func (p *Printer) FormatTime(t time.Time, layout string, loc *time.Location) string {
+	if layout == "" {
+		layout = p.loc().DateLayout
+	}
+	return humanize.FormatTime(t, layout, loc)
+}
+
+// FormatRelativeTime formats a time relative to now, e.g. "3 minutes ago" or
+// "in 2 hours"
+func FormatRelativeTime(t time.Time) string {
+	return singleton.FormatRelativeTime(t)
+}
+
+// FormatRelativeTime formats a time relative to now, e.g. "3 minutes ago" or
+// "in 2 hours"
+func (p *Printer) FormatRelativeTime(t time.Time) string {
+	return humanize.FormatRelativeTime(t, time.Now())
+}
+
+// FormatPercent formats a percentage with the passed number of decimal places,
+// e.g. FormatPercent(45.678, 1) returns "45.7%"
+func FormatPercent(n float64, precision int) string {
+	return singleton.FormatPercent(n, precision)
+}
+
+// FormatPercent formats a percentage with the passed number of decimal places,
+// e.g. FormatPercent(45.678, 1) returns "45.7%"
+func (p *Printer) FormatPercent(n float64, precision int) string {
+	return p.loc().Decimals(humanize.FormatPercent(n, precision))
+}
+
+// FormatThousands formats a number with its thousands grouped by the locale's
+// group separator, e.g. "1,234,567"
+func FormatThousands(n int64) string {
+	return singleton.FormatThousands(n)
+}
+
+// FormatThousands formats a number with its thousands grouped by the locale's
+// group separator, e.g. "1,234,567"
+func (p *Printer) FormatThousands(n int64) string {
+	return p.loc().Number(strconv.FormatInt(n, 10), "")
+}
+
+// FormatBool formats a boolean as "✓" or "✗"
+func FormatBool(b bool) string {
+	return singleton.FormatBool(b)
+}
+
+// FormatBool formats a boolean as "✓" or "✗"
+func (p *Printer) FormatBool(b bool) string {
+	return humanize.FormatBool(b, "", "")
+}
+
+// FormatYesNo formats a boolean as "yes" or "no"
+func FormatYesNo(b bool) string {
+	return singleton.FormatYesNo(b)
+}
+
+// FormatYesNo formats a boolean as "yes" or "no"
+func (p *Printer) FormatYesNo(b bool) string {
+	return humanize.FormatBool(b, "yes", "no")
+}
+
+// FormatEmpty returns the passed placeholder if the value is empty, and
+// otherwise returns the value
+func FormatEmpty(value, placeholder string) string {
+	return singleton.FormatEmpty(value, placeholder)
+}
+
+// FormatEmpty returns the passed placeholder if the value is empty, and
+// otherwise returns the value
+func (p *Printer) FormatEmpty(value, placeholder string) string {
+	formatted, _ := (&humanize.Format{Empty: placeholder}).Apply(value)
+	return formatted
+}
+
+func humanizeFormats(formats map[string]*ValueFormat) map[string]*humanize.Format {
+	if formats == nil {
+		return nil
+	}
+	converted := make(map[string]*humanize.Format, len(formats))
+	for key, format := range formats {
+		converted[key] = &humanize.Format{
+			Type:      format.Type,
+			SI:        format.SI,
+			Layout:    format.Layout,
+			Location:  format.Location,
+			Precision: format.Precision,
+			Separator: format.Separator,
+			True:      format.True,
+			False:     format.False,
+			Empty:     format.Empty,
+		}
+	}
+	return converted
+}
+
+func valueFormats(formats map[string]*humanize.Format) map[string]*ValueFormat {
+	if formats == nil {
+		return nil
+	}
+	converted := make(map[string]*ValueFormat, len(formats))
+	for key, format := range formats {
+		converted[key] = &ValueFormat{
+			Type:      format.Type,
+			SI:        format.SI,
+			Layout:    format.Layout,
+			Location:  format.Location,
+			Precision: format.Precision,
+			Separator: format.Separator,
+			True:      format.True,
+			False:     format.False,
+			Empty:     format.Empty,
+		}
+	}
+	return converted
+}
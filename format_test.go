@@ -0,0 +1,35 @@
+package printer
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/humanize"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestFormatHelpers() {
+	suite.Equal("1.5 KiB", FormatBytes(1536, false))
+	suite.Equal("2m30s", FormatDuration(150*time.Second))
+	suite.Equal("2021-03-10 12:30", FormatTime(time.Date(2021, 3, 10, 12, 30, 0, 0, time.UTC), "", time.UTC))
+	suite.Equal("5 minutes ago", FormatRelativeTime(time.Now().Add(-5*time.Minute-time.Second)))
+	suite.Equal("45.7%", FormatPercent(45.678, 1))
+	suite.Equal("-1,234,567", FormatThousands(-1234567))
+	suite.Equal("✓", FormatBool(true))
+	suite.Equal("no", FormatYesNo(false))
+	suite.Equal("-", FormatEmpty("", "-"))
+	suite.Equal("value", FormatEmpty("value", "-"))
+}
+
+func (suite *PrinterSuite) TestAddTableStencilWithFormats() {
+	stencil := &TableStencil{
+		ID:          "test id",
+		ColumnOrder: []string{"size"},
+		Formats:     map[string]*ValueFormat{"size": {Type: BytesFormat, SI: true, Empty: "-"}},
+	}
+	suite.Stenciller.On("AddTableStencil", mock.MatchedBy(func(s *stenciller.TableStencil) bool {
+		return *s.Formats["size"] == humanize.Format{Type: humanize.Bytes, SI: true, Empty: "-"}
+	})).Return(nil)
+	err := AddTableStencil(stencil)
+	suite.NoError(err)
+}
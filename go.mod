@@ -1,13 +1,23 @@
 module github.com/tomguerney/printer
 
-go 1.15
-
-replace github.com/tomguerney/interpolator => /Users/tguerney/code/interpolator
+go 1.21
 
 require (
 	github.com/fatih/color v1.10.0
-	github.com/manifoldco/promptui v0.8.0 // indirect
+	github.com/manifoldco/promptui v0.8.0
+	github.com/mattn/go-isatty v0.0.12
 	github.com/rs/zerolog v1.21.0
 	github.com/stretchr/testify v1.7.0
-	github.com/tomguerney/interpolator v0.0.0-20210729113513-fe324ca20c47 // indirect
+	golang.org/x/sys v0.0.0-20210119212857-b64e53b001e4
+)
+
+require (
+	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/juju/ansiterm v0.0.0-20180109212912-720a0952cc2a // indirect
+	github.com/lunixbochs/vtclean v0.0.0-20180621232353-2d01aacdc34a // indirect
+	github.com/mattn/go-colorable v0.1.8 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.1.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
@@ -13,6 +13,17 @@ func New() *Colorer {
 	return &Colorer{}
 }
 
+// SetEnabled sets whether text is colored by every Colorer
+func SetEnabled(enabled bool) {
+	color.NoColor = !enabled
+}
+
+// Exists returns whether text can be colored the color with the passed name
+func Exists(colorName string) bool {
+	_, ok := (&Colorer{}).Color("", colorName)
+	return ok
+}
+
 // Color transforms a string into one of the available colors. If the color is
 // not available the string will not be coloured and ok will be false. 
 func (c *Colorer) Color(text string, colorName string) (colored string, ok bool) {
@@ -33,6 +44,32 @@ func (c *Colorer) Color(text string, colorName string) (colored string, ok bool)
 		return c.Cyan(text), true
 	case "white":
 		return c.White(text), true
+	case "bold":
+		return c.Bold(text), true
+	case "dim":
+		return c.Dim(text), true
+	case "italic":
+		return c.Italic(text), true
+	case "underline":
+		return c.Underline(text), true
+	case "reverse":
+		return c.Reverse(text), true
+	case "bgblack":
+		return color.New(color.BgBlack).Sprint(text), true
+	case "bgred":
+		return color.New(color.BgRed).Sprint(text), true
+	case "bggreen":
+		return color.New(color.BgGreen).Sprint(text), true
+	case "bgyellow":
+		return color.New(color.BgYellow).Sprint(text), true
+	case "bgblue":
+		return color.New(color.BgBlue).Sprint(text), true
+	case "bgmagenta":
+		return color.New(color.BgMagenta).Sprint(text), true
+	case "bgcyan":
+		return color.New(color.BgCyan).Sprint(text), true
+	case "bgwhite":
+		return color.New(color.BgWhite).Sprint(text), true
 	default:
 		return text, false
 	}
@@ -77,3 +114,28 @@ func (c *Colorer) Cyan(text string) string {
 func (c *Colorer) White(text string) string {
 	return color.WhiteString(text)
 }
+
+// Bold returns bold text
+func (c *Colorer) Bold(text string) string {
+	return color.New(color.Bold).Sprint(text)
+}
+
+// Dim returns dim text
+func (c *Colorer) Dim(text string) string {
+	return color.New(color.Faint).Sprint(text)
+}
+
+// Italic returns italic text
+func (c *Colorer) Italic(text string) string {
+	return color.New(color.Italic).Sprint(text)
+}
+
+// Underline returns underlined text
+func (c *Colorer) Underline(text string) string {
+	return color.New(color.Underline).Sprint(text)
+}
+
+// Reverse returns text with its foreground and background colors swapped
+func (c *Colorer) Reverse(text string) string {
+	return color.New(color.ReverseVideo).Sprint(text)
+}
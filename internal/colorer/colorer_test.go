@@ -29,6 +29,19 @@ func (suite *ColorerSuite) TestCorrectColorNames() {
 		{"magenta"},
 		{"cyan"},
 		{"white"},
+		{"bold"},
+		{"dim"},
+		{"italic"},
+		{"underline"},
+		{"reverse"},
+		{"bgblack"},
+		{"bgred"},
+		{"bggreen"},
+		{"bgyellow"},
+		{"bgblue"},
+		{"bgmagenta"},
+		{"bgcyan"},
+		{"bgwhite"},
 	}
 	for _, tt := range colorTests {
 		suite.Run(tt.color, func() {
@@ -0,0 +1,32 @@
+package dates
+
+import (
+	"strings"
+	"time"
+)
+
+var layouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	"02 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// Parse parses the passed value as a date in any of a number of common layouts
+func Parse(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
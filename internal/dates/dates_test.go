@@ -0,0 +1,27 @@
+package dates
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DatesSuite struct {
+	suite.Suite
+}
+
+func (suite *DatesSuite) TestParse() {
+	expected := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2021-03-01", " 2021-03-01T00:00:00Z ", "01 Mar 2021", "Mar 1, 2021"} {
+		actual, ok := Parse(value)
+		suite.True(ok, value)
+		suite.True(expected.Equal(actual), value)
+	}
+	_, ok := Parse("unknown")
+	suite.False(ok)
+}
+
+func TestDatesSuite(t *testing.T) {
+	suite.Run(t, new(DatesSuite))
+}
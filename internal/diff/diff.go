@@ -0,0 +1,253 @@
+package diff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Operations
+const (
+	Equal  = "equal"
+	Delete = "delete"
+	Insert = "insert"
+)
+
+// Edit is a single step in transforming one sequence into another. Text is the
+// element that is kept, deleted or inserted.
+type Edit struct {
+	Op   string
+	Text string
+}
+
+// Hunk is a run of edits containing changes, surrounded by unchanged context.
+// OldStart and NewStart are the 1-based line numbers the hunk starts at in the
+// old and new text, and OldLines and NewLines are the number of lines it spans
+// in each.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Edits              []Edit
+}
+
+// Lines returns the shortest edit script that transforms the lines of the old
+// text into the lines of the new text, as found by Myers' diff algorithm
+func Lines(oldText, newText string) []Edit {
+	return Diff(SplitLines(oldText), SplitLines(newText))
+}
+
+// Words returns the shortest edit script that transforms the words of the old
+// line into the words of the new line. Runs of letters and digits, runs of
+// whitespace, and every other character are each a word.
+func Words(oldLine, newLine string) []Edit {
+	return Diff(splitWords(oldLine), splitWords(newLine))
+}
+
+// SplitLines splits text into lines. A trailing newline doesn't start another
+// line.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Diff returns the shortest edit script that transforms a into b, using Myers'
+// O(ND) diff algorithm
+func Diff(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	trace := [][]int{}
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		if x, y := furthest(a, b, v, d, offset); x >= n && y >= m {
+			break
+		}
+	}
+	return backtrack(a, b, trace, offset)
+}
+
+// furthest advances each diagonal of v to its furthest reaching path with d
+// changes, and returns the end of the last path found once it reaches the end
+// of both sequences
+func furthest(a, b []string, v []int, d, offset int) (int, int) {
+	for k := -d; k <= d; k += 2 {
+		var x int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			x = v[offset+k+1]
+		} else {
+			x = v[offset+k-1] + 1
+		}
+		y := x - k
+		for x < len(a) && y < len(b) && a[x] == b[y] {
+			x++
+			y++
+		}
+		v[offset+k] = x
+		if x >= len(a) && y >= len(b) {
+			return x, y
+		}
+	}
+	return -1, -1
+}
+
+// backtrack walks back through the furthest reaching paths of each number of
+// changes to recover the edits
+func backtrack(a, b []string, trace [][]int, offset int) []Edit {
+	x, y := len(a), len(b)
+	reversed := []Edit{}
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			reversed = append(reversed, Edit{Equal, a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			reversed = append(reversed, Edit{Insert, b[y-1]})
+			y--
+		} else {
+			reversed = append(reversed, Edit{Delete, a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		reversed = append(reversed, Edit{Equal, a[x-1]})
+		x--
+		y--
+	}
+	edits := make([]Edit, len(reversed))
+	for i, edit := range reversed {
+		edits[len(reversed)-1-i] = edit
+	}
+	return edits
+}
+
+// Hunks groups edits into hunks of changes, each with up to the passed number
+// of unchanged lines of context around it. Changes separated by no more than
+// twice the context are in the same hunk.
+func Hunks(edits []Edit, context int) []*Hunk {
+	if context < 0 {
+		context = 0
+	}
+	oldPos := make([]int, len(edits)+1)
+	newPos := make([]int, len(edits)+1)
+	for i, edit := range edits {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if edit.Op != Insert {
+			oldPos[i+1]++
+		}
+		if edit.Op != Delete {
+			newPos[i+1]++
+		}
+	}
+	hunks := []*Hunk{}
+	i := 0
+	for {
+		for i < len(edits) && edits[i].Op == Equal {
+			i++
+		}
+		if i == len(edits) {
+			return hunks
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for {
+			for end < len(edits) && edits[end].Op != Equal {
+				end++
+			}
+			next := end
+			for next < len(edits) && edits[next].Op == Equal {
+				next++
+			}
+			if next < len(edits) && next-end <= 2*context {
+				end = next
+				continue
+			}
+			if end+context < next {
+				next = end + context
+			}
+			end = next
+			break
+		}
+		hunks = append(hunks, &Hunk{
+			OldStart: lineStart(oldPos[start], oldPos[end]),
+			OldLines: oldPos[end] - oldPos[start],
+			NewStart: lineStart(newPos[start], newPos[end]),
+			NewLines: newPos[end] - newPos[start],
+			Edits:    edits[start:end],
+		})
+		i = end
+	}
+}
+
+// lineStart returns the 1-based line a hunk starts at, which by convention is
+// the line before the hunk if it spans no lines
+func lineStart(start, end int) int {
+	if start == end {
+		return start
+	}
+	return start + 1
+}
+
+// Blocks splits the edits of a hunk into runs of unchanged lines and runs of
+// changes. The deletions of each run of changes come before its insertions.
+func Blocks(edits []Edit) [][]Edit {
+	blocks := [][]Edit{}
+	for i := 0; i < len(edits); {
+		if edits[i].Op == Equal {
+			j := i
+			for j < len(edits) && edits[j].Op == Equal {
+				j++
+			}
+			blocks = append(blocks, edits[i:j])
+			i = j
+			continue
+		}
+		deletes, inserts := []Edit{}, []Edit{}
+		for ; i < len(edits) && edits[i].Op != Equal; i++ {
+			if edits[i].Op == Delete {
+				deletes = append(deletes, edits[i])
+			} else {
+				inserts = append(inserts, edits[i])
+			}
+		}
+		blocks = append(blocks, append(deletes, inserts...))
+	}
+	return blocks
+}
+
+func splitWords(line string) []string {
+	words := []string{}
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		words = append(words, string(runes[i:j]))
+		i = j
+	}
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
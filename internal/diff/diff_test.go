@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffSuite struct {
+	suite.Suite
+}
+
+// apply applies edits to a, checking they are consistent with it, and returns
+// the result
+func (suite *DiffSuite) apply(a []string, edits []Edit) []string {
+	result := []string{}
+	i := 0
+	for _, edit := range edits {
+		switch edit.Op {
+		case Equal:
+			suite.Equal(a[i], edit.Text)
+			result = append(result, edit.Text)
+			i++
+		case Delete:
+			suite.Equal(a[i], edit.Text)
+			i++
+		case Insert:
+			result = append(result, edit.Text)
+		}
+	}
+	suite.Equal(len(a), i)
+	return result
+}
+
+func changes(edits []Edit) int {
+	n := 0
+	for _, edit := range edits {
+		if edit.Op != Equal {
+			n++
+		}
+	}
+	return n
+}
+
+func (suite *DiffSuite) TestDiff() {
+	var tests = []struct {
+		a, b    string
+		changes int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"abcabba", "cbabac", 5},
+		{"abcdef", "abxdef", 2},
+		{"xaxbxc", "abc", 3},
+	}
+	for _, tt := range tests {
+		a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+		if tt.a == "" {
+			a = nil
+		}
+		if tt.b == "" {
+			b = nil
+		}
+		edits := Diff(a, b)
+		suite.Equal(tt.changes, changes(edits), "%v -> %v", tt.a, tt.b)
+		suite.Equal(append([]string{}, b...), suite.apply(a, edits))
+	}
+}
+
+func (suite *DiffSuite) TestLines() {
+	edits := Lines("a\nb\nc\n", "a\nB\nc\n")
+	suite.Equal([]Edit{
+		{Equal, "a"},
+		{Delete, "b"},
+		{Insert, "B"},
+		{Equal, "c"},
+	}, edits)
+}
+
+func (suite *DiffSuite) TestWords() {
+	edits := Words("replicas: 3", "replicas: 5")
+	suite.Equal([]Edit{
+		{Equal, "replicas"},
+		{Equal, ":"},
+		{Equal, " "},
+		{Delete, "3"},
+		{Insert, "5"},
+	}, edits)
+}
+
+func (suite *DiffSuite) TestHunks() {
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	newText := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n"
+	hunks := Hunks(Lines(old, newText), 2)
+	suite.Len(hunks, 2)
+	suite.Equal(&Hunk{OldStart: 1, OldLines: 5, NewStart: 1, NewLines: 5, Edits: hunks[0].Edits}, hunks[0])
+	suite.Equal(&Hunk{OldStart: 10, OldLines: 3, NewStart: 10, NewLines: 3, Edits: hunks[1].Edits}, hunks[1])
+	suite.Len(Hunks(Lines(old, newText), 4), 1)
+	suite.Empty(Hunks(Lines(old, old), 3))
+}
+
+func (suite *DiffSuite) TestHunkWithNoOldLines() {
+	hunks := Hunks(Lines("", "a\n"), 3)
+	suite.Equal(&Hunk{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 1, Edits: []Edit{{Insert, "a"}}}, hunks[0])
+}
+
+func (suite *DiffSuite) TestBlocks() {
+	edits := []Edit{{Equal, "a"}, {Insert, "x"}, {Delete, "b"}, {Delete, "c"}, {Equal, "d"}}
+	suite.Equal([][]Edit{
+		{{Equal, "a"}},
+		{{Delete, "b"}, {Delete, "c"}, {Insert, "x"}},
+		{{Equal, "d"}},
+	}, Blocks(edits))
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
@@ -0,0 +1,188 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Export formats
+const (
+	CSV      = "csv"
+	TSV      = "tsv"
+	Markdown = "markdown"
+	HTML     = "html"
+)
+
+// Table is a table to be exported. Headers may be empty, in which case formats
+// that require a header row use the column names. Colors holds the color of
+// each column, or an empty string if the column isn't colored.
+type Table struct {
+	Headers []string
+	Columns []string
+	Rows    [][]string
+	Colors  []string
+}
+
+// Export writes the passed Table to the passed io.Writer in the passed format.
+// It returns an error if the format isn't one of CSV, TSV, Markdown or HTML.
+func Export(table *Table, format string, w io.Writer) error {
+	switch format {
+	case CSV:
+		return exportCSV(table, w)
+	case TSV:
+		return exportTSV(table, w)
+	case Markdown:
+		return exportMarkdown(table, w)
+	case HTML:
+		return exportHTML(table, w)
+	default:
+		return fmt.Errorf("Unknown export format %v", format)
+	}
+}
+
+// exportCSV writes the table as RFC 4180 CSV, escaping cells that a
+// spreadsheet would interpret as a formula
+func exportCSV(table *Table, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+	if len(table.Headers) > 0 {
+		if err := writer.Write(escapeFormulas(table.Headers)); err != nil {
+			return err
+		}
+	}
+	for _, row := range table.Rows {
+		if err := writer.Write(escapeFormulas(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// exportTSV writes the table as tab-separated values. Tabs and newlines within
+// cells are replaced with spaces, as TSV has no way to escape them.
+func exportTSV(table *Table, w io.Writer) error {
+	rows := table.Rows
+	if len(table.Headers) > 0 {
+		rows = append([][]string{table.Headers}, rows...)
+	}
+	replacer := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	for _, row := range rows {
+		cells := escapeFormulas(row)
+		for i, cell := range cells {
+			cells[i] = replacer.Replace(cell)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportMarkdown writes the table as a GitHub Flavored Markdown table
+func exportMarkdown(table *Table, w io.Writer) error {
+	headers := headerRow(table)
+	width := columnCount(table, headers)
+	replacer := strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+	writeRow := func(row []string) error {
+		cells := make([]string, width)
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = replacer.Replace(row[i])
+			}
+		}
+		_, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+		return err
+	}
+	if err := writeRow(headers); err != nil {
+		return err
+	}
+	divider := make([]string, width)
+	for i := range divider {
+		divider[i] = "---"
+	}
+	if err := writeRow(divider); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var cssColors = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// exportHTML writes the table as a standalone HTML document. Colored columns
+// are given a CSS class of their color name, which the document styles.
+func exportHTML(table *Table, w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<style>\n")
+	b.WriteString("table { border-collapse: collapse; }\n")
+	b.WriteString("th, td { padding: 0.25em 1em; text-align: left; }\n")
+	for _, color := range cssColors {
+		fmt.Fprintf(&b, ".%s { color: %s; }\n", color, color)
+	}
+	b.WriteString("</style>\n</head>\n<body>\n<table>\n<thead>\n<tr>")
+	for _, header := range headerRow(table) {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(header))
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range table.Rows {
+		b.WriteString("<tr>")
+		for i, cell := range row {
+			if i < len(table.Colors) && table.Colors[i] != "" {
+				fmt.Fprintf(&b, "<td class=\"%s\">", html.EscapeString(table.Colors[i]))
+			} else {
+				b.WriteString("<td>")
+			}
+			b.WriteString(strings.ReplaceAll(html.EscapeString(cell), "\n", "<br>"))
+			b.WriteString("</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n</body>\n</html>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func headerRow(table *Table) []string {
+	if len(table.Headers) > 0 {
+		return table.Headers
+	}
+	return table.Columns
+}
+
+func columnCount(table *Table, headers []string) int {
+	count := len(headers)
+	for _, row := range table.Rows {
+		if len(row) > count {
+			count = len(row)
+		}
+	}
+	return count
+}
+
+// escapeFormulas returns a copy of the passed cells with any cell that starts
+// with a character a spreadsheet would interpret as the start of a formula
+// prefixed with a single quote. Numbers, including negative numbers, are left
+// as they are.
+func escapeFormulas(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = cell
+		if cell == "" || !strings.ContainsAny(cell[:1], "=+-@\t\r") {
+			continue
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			continue
+		}
+		escaped[i] = "'" + cell
+	}
+	return escaped
+}
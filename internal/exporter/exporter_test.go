@@ -0,0 +1,104 @@
+package exporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ExporterSuite struct {
+	suite.Suite
+	Table *Table
+	Out   *bytes.Buffer
+}
+
+func (suite *ExporterSuite) SetupTest() {
+	suite.Out = new(bytes.Buffer)
+	suite.Table = &Table{
+		Headers: []string{"Name", "Status"},
+		Columns: []string{"name", "status"},
+		Rows: [][]string{
+			{"web", "running"},
+			{"db, primary", "stopped"},
+		},
+		Colors: []string{"", "green"},
+	}
+}
+
+func (suite *ExporterSuite) TestCSV() {
+	err := Export(suite.Table, CSV, suite.Out)
+	suite.NoError(err)
+	expected := "Name,Status\r\nweb,running\r\n\"db, primary\",stopped\r\n"
+	suite.Equal(expected, suite.Out.String())
+}
+
+func (suite *ExporterSuite) TestCSVWithoutHeaders() {
+	suite.Table.Headers = nil
+	err := Export(suite.Table, CSV, suite.Out)
+	suite.NoError(err)
+	suite.Equal("web,running\r\n\"db, primary\",stopped\r\n", suite.Out.String())
+}
+
+func (suite *ExporterSuite) TestCSVEscapesFormulas() {
+	suite.Table.Rows = [][]string{
+		{"=SUM(A1:A2)", "+1+1"},
+		{"@cmd", "-2+3"},
+		{"-12.5", "\tindented"},
+	}
+	err := Export(suite.Table, CSV, suite.Out)
+	suite.NoError(err)
+	expected := "Name,Status\r\n'=SUM(A1:A2),'+1+1\r\n'@cmd,'-2+3\r\n-12.5,'\tindented\r\n"
+	suite.Equal(expected, suite.Out.String())
+}
+
+func (suite *ExporterSuite) TestTSV() {
+	suite.Table.Rows = append(suite.Table.Rows, []string{"tab\there", "new\nline"})
+	err := Export(suite.Table, TSV, suite.Out)
+	suite.NoError(err)
+	expected := "Name\tStatus\nweb\trunning\ndb, primary\tstopped\ntab here\tnew line\n"
+	suite.Equal(expected, suite.Out.String())
+}
+
+func (suite *ExporterSuite) TestMarkdown() {
+	suite.Table.Rows = append(suite.Table.Rows, []string{"a|b", "multi\nline"})
+	err := Export(suite.Table, Markdown, suite.Out)
+	suite.NoError(err)
+	expected := strings.Join([]string{
+		"| Name | Status |",
+		"| --- | --- |",
+		"| web | running |",
+		"| db, primary | stopped |",
+		"| a\\|b | multi<br>line |",
+		"",
+	}, "\n")
+	suite.Equal(expected, suite.Out.String())
+}
+
+func (suite *ExporterSuite) TestMarkdownWithoutHeaders() {
+	suite.Table.Headers = nil
+	err := Export(suite.Table, Markdown, suite.Out)
+	suite.NoError(err)
+	suite.True(strings.HasPrefix(suite.Out.String(), "| name | status |\n| --- | --- |\n"))
+}
+
+func (suite *ExporterSuite) TestHTML() {
+	suite.Table.Rows = [][]string{{"<web>", "running"}}
+	err := Export(suite.Table, HTML, suite.Out)
+	suite.NoError(err)
+	actual := suite.Out.String()
+	suite.True(strings.HasPrefix(actual, "<!DOCTYPE html>\n"))
+	suite.Contains(actual, ".green { color: green; }")
+	suite.Contains(actual, "<thead>\n<tr><th>Name</th><th>Status</th></tr>\n</thead>")
+	suite.Contains(actual, "<tr><td>&lt;web&gt;</td><td class=\"green\">running</td></tr>")
+}
+
+func (suite *ExporterSuite) TestUnknownFormat() {
+	err := Export(suite.Table, "xml", suite.Out)
+	suite.EqualError(err, "Unknown export format xml")
+}
+
+func TestExporterSuite(t *testing.T) {
+	suite.Run(t, new(ExporterSuite))
+}
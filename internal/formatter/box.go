@@ -0,0 +1,161 @@
+package formatter
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Border styles
+const (
+	SingleBorder  = "single"
+	DoubleBorder  = "double"
+	RoundedBorder = "rounded"
+	HeavyBorder   = "heavy"
+	ASCIIBorder   = "ascii"
+)
+
+type border struct {
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical string
+}
+
+var borders = map[string]border{
+	SingleBorder:  {"┌", "┐", "└", "┘", "─", "│"},
+	DoubleBorder:  {"╔", "╗", "╚", "╝", "═", "║"},
+	RoundedBorder: {"╭", "╮", "╰", "╯", "─", "│"},
+	HeavyBorder:   {"┏", "┓", "┗", "┛", "━", "┃"},
+	ASCIIBorder:   {"+", "+", "+", "+", "-", "|"},
+}
+
+// BoxOptions are the options used to render a box
+type BoxOptions struct {
+	Border     string
+	Padding    int
+	Color      string
+	TitleColor string
+	Width      int
+}
+
+// Box renders the passed body inside a bordered box with the passed title set
+// in the top border, and returns the lines of the box.
+//
+// The box is sized to fit its widest line, measured without ANSI escape codes,
+// up to the Width option. Body lines that don't fit are wrapped, and a title
+// that doesn't fit is truncated, or left out if not even one column of it
+// fits. A Width of 0 or less leaves the box unbounded. The Padding option sets
+// the spaces between the vertical borders and the body, and is reduced if the
+// box is too narrow for it. The Color and TitleColor options color the border
+// and title respectively.
+func (f *Formatter) Box(title, body string, opts *BoxOptions) []string {
+	b, ok := borders[opts.Border]
+	if !ok {
+		b = borders[SingleBorder]
+	}
+	padding := opts.Padding
+	maxInner := 0
+	if opts.Width > 0 {
+		padding = min(padding, max(0, (opts.Width-3)/2))
+		maxInner = max(1, opts.Width-2-2*padding)
+	}
+	lines := wrap(body, &WrapOptions{Width: maxInner})
+	inner := 0
+	for _, line := range lines {
+		if l := lenNoAnsi(line); l > inner {
+			inner = l
+		}
+	}
+	span := inner + 2*padding
+	if title != "" && span < lenNoAnsi(title)+4 {
+		span = lenNoAnsi(title) + 4
+		if opts.Width > 0 && span > opts.Width-2 {
+			span = max(opts.Width-2, inner+2*padding)
+		}
+		inner = span - 2*padding
+	}
+	if span < 5 {
+		title = ""
+	}
+
+	top := b.topLeft + strings.Repeat(b.horizontal, span) + b.topRight
+	if title != "" {
+		title = truncate(title, span-4)
+		rest := span - 3 - lenNoAnsi(title)
+		top = f.colorize(b.topLeft+b.horizontal+" ", opts.Color) +
+			f.colorize(title, opts.TitleColor) +
+			f.colorize(" "+strings.Repeat(b.horizontal, rest)+b.topRight, opts.Color)
+	} else {
+		top = f.colorize(top, opts.Color)
+	}
+
+	pad := strings.Repeat(" ", padding)
+	vertical := f.colorize(b.vertical, opts.Color)
+	boxed := []string{top}
+	for _, line := range lines {
+		fill := strings.Repeat(" ", inner-lenNoAnsi(line))
+		boxed = append(boxed, vertical+pad+line+fill+pad+vertical)
+	}
+	bottom := b.bottomLeft + strings.Repeat(b.horizontal, span) + b.bottomRight
+	return append(boxed, f.colorize(bottom, opts.Color))
+}
+
+// Heading renders the passed text as a section heading and returns its lines.
+// A level 1 heading is underlined with a double line and a level 2 heading with
+// a single line. Any other level is rendered as a single line ruled out to the
+// passed width.
+func (f *Formatter) Heading(level int, text string, width int) []string {
+	switch level {
+	case 1:
+		return []string{text, strings.Repeat("═", lenNoAnsi(text))}
+	case 2:
+		return []string{text, strings.Repeat("─", lenNoAnsi(text))}
+	default:
+		heading := "── " + text + " "
+		fill := width - lenNoAnsi(heading)
+		if fill < 2 {
+			fill = 2
+		}
+		return []string{heading + strings.Repeat("─", fill)}
+	}
+}
+
+// Rule returns a horizontal rule of the passed width
+func (f *Formatter) Rule(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return strings.Repeat("─", width)
+}
+
+// truncate shortens the passed text to at most width terminal columns,
+// replacing the last column with an ellipsis if the text is shortened. ANSI
+// escape codes are kept whole and occupy no columns, and any color span left
+// open by the shortening is reset.
+func truncate(text string, width int) string {
+	if lenNoAnsi(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	used, colored := 0, false
+	for len(text) > 0 {
+		if loc := ansiRegexp.FindStringIndex(text); loc != nil && loc[0] == 0 {
+			b.WriteString(text[:loc[1]])
+			colored = true
+			text = text[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		if used+runeWidth(r) > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += runeWidth(r)
+		text = text[size:]
+	}
+	b.WriteString("…")
+	if colored {
+		b.WriteString(ansiReset)
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package formatter
+
+func (suite *FormatterSuite) TestBox() {
+	expected := []string{
+		"┌─ Title ─────┐",
+		"│ first line  │",
+		"│ second line │",
+		"│   indented  │",
+		"└─────────────┘",
+	}
+	body := "first line\nsecond line\n  indented"
+	actual := suite.Formatter.Box("Title", body, &BoxOptions{Padding: 1})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestBoxWithoutTitle() {
+	expected := []string{
+		"+-------+",
+		"|  abc  |",
+		"+-------+",
+	}
+	actual := suite.Formatter.Box("", "abc", &BoxOptions{Border: ASCIIBorder, Padding: 2})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestBoxGrowsToFitTitle() {
+	expected := []string{
+		"╔═ Long title ═╗",
+		"║ a            ║",
+		"╚══════════════╝",
+	}
+	actual := suite.Formatter.Box("Long title", "a", &BoxOptions{Border: DoubleBorder, Padding: 1})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestBoxWrapsToWidth() {
+	expected := []string{
+		"┌────────────┐",
+		"│ some words │",
+		"│ that wrap  │",
+		"└────────────┘",
+	}
+	actual := suite.Formatter.Box("", "some words that wrap", &BoxOptions{Padding: 1, Width: 15})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestBoxBreaksLongWordsWithinWidth() {
+	expected := []string{
+		"┌─ A long titl… ─┐",
+		"│ a              │",
+		"│ longlonglonglo │",
+		"│ ngword         │",
+		"└────────────────┘",
+	}
+	actual := suite.Formatter.Box("A long title here", "a longlonglonglongword", &BoxOptions{Padding: 1, Width: 18})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestBoxWithNarrowWidth() {
+	suite.Equal([]string{"┌─ … ─┐", "│ x   │", "└─────┘"}, suite.Formatter.Box("hello", "x", &BoxOptions{Padding: 1, Width: 7}))
+	suite.Equal([]string{"┌──┐", "│x │", "└──┘"}, suite.Formatter.Box("hello", "x", &BoxOptions{Padding: 1, Width: 4}))
+	suite.Equal([]string{"┌─┐", "│x│", "└─┘"}, suite.Formatter.Box("hello", "x", &BoxOptions{Padding: 1, Width: 3}))
+	suite.Equal([]string{"┌─┐", "│x│", "└─┘"}, suite.Formatter.Box("hello", "x", &BoxOptions{Padding: 2, Width: 1}))
+}
+
+func (suite *FormatterSuite) TestBoxTruncatesColoredTitle() {
+	title := "\u001b[31mColored title\u001b[0m"
+	actual := suite.Formatter.Box(title, "a", &BoxOptions{Width: 12})
+	suite.Equal("┌─ \u001b[31mColor…\u001b[0m ─┐", actual[0])
+}
+
+func (suite *FormatterSuite) TestBoxIgnoresAnsiWidth() {
+	colored := "\u001b[31mred\u001b[0m"
+	expected := []string{
+		"┌─────┐",
+		"│ " + colored + " │",
+		"└─────┘",
+	}
+	actual := suite.Formatter.Box("", colored, &BoxOptions{Padding: 1})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestBoxWithColoredBorder() {
+	suite.Colorer.On("Color", "┌─ ", "blue").Return("[┌─ ]", true)
+	suite.Colorer.On("Color", "T", "red").Return("[T]", true)
+	suite.Colorer.On("Color", " ─┐", "blue").Return("[ ─┐]", true)
+	suite.Colorer.On("Color", "│", "blue").Return("[│]", true)
+	suite.Colorer.On("Color", "└─────┘", "blue").Return("[└─────┘]", true)
+	expected := []string{
+		"[┌─ ][T][ ─┐]",
+		"[│] ab  [│]",
+		"[└─────┘]",
+	}
+	actual := suite.Formatter.Box("T", "ab", &BoxOptions{Padding: 1, Color: "blue", TitleColor: "red"})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestHeading() {
+	suite.Equal([]string{"Title", "═════"}, suite.Formatter.Heading(1, "Title", 20))
+	suite.Equal([]string{"Section", "───────"}, suite.Formatter.Heading(2, "Section", 20))
+	suite.Equal([]string{"── Sub ─────────────"}, suite.Formatter.Heading(3, "Sub", 20))
+}
+
+func (suite *FormatterSuite) TestRule() {
+	suite.Equal("─────", suite.Formatter.Rule(5))
+	suite.Equal("", suite.Formatter.Rule(0))
+}
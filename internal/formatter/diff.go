@@ -0,0 +1,279 @@
+package formatter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tomguerney/printer/internal/diff"
+)
+
+// DiffOptions are the options used to render a diff. Context is the number of
+// unchanged lines shown around each change. If SideBySide is true the old and
+// new text are shown in two columns within Width. If Words is true the words
+// that differ within changed lines are highlighted. OldName and NewName are
+// shown in the header of the diff if set.
+type DiffOptions struct {
+	Context    int
+	SideBySide bool
+	Width      int
+	Words      bool
+	OldName    string
+	NewName    string
+}
+
+const (
+	deleteColor    = "red"
+	insertColor    = "green"
+	changeColor    = "yellow"
+	hunkColor      = "cyan"
+	highlightStyle = "reverse"
+	sideSeparator  = " │ "
+)
+
+// Diff renders the differences between the old and new text as a colored
+// unified diff, or side by side, and returns its lines. It returns no lines if
+// the texts are the same.
+func (f *Formatter) Diff(oldText, newText string, opts *DiffOptions) []string {
+	hunks := diff.Hunks(diff.Lines(oldText, newText), opts.Context)
+	if len(hunks) == 0 {
+		return nil
+	}
+	if opts.SideBySide {
+		return f.sideBySide(hunks, opts)
+	}
+	lines := []string{}
+	if opts.OldName != "" || opts.NewName != "" {
+		lines = append(lines,
+			f.colorize("--- "+opts.OldName, "bold"),
+			f.colorize("+++ "+opts.NewName, "bold"))
+	}
+	for _, hunk := range hunks {
+		lines = append(lines, f.colorize(hunkHeader(hunk), hunkColor))
+		for _, block := range diff.Blocks(hunk.Edits) {
+			if block[0].Op == diff.Equal {
+				for _, edit := range block {
+					lines = append(lines, " "+edit.Text)
+				}
+				continue
+			}
+			deletes, inserts := split(block)
+			for i, edit := range deletes {
+				other, ok := partner(inserts, i)
+				lines = append(lines, f.changedLine("-", edit.Text, other, ok, diff.Delete, deleteColor, opts.Words))
+			}
+			for i, edit := range inserts {
+				other, ok := partner(deletes, i)
+				lines = append(lines, f.changedLine("+", edit.Text, other, ok, diff.Insert, insertColor, opts.Words))
+			}
+		}
+	}
+	return lines
+}
+
+// DiffMaps renders the keys added to, removed from and changed between the old
+// and new maps, sorted by key, and returns the lines. Added keys are prefixed
+// with "+", removed keys with "-", and changed keys with "~" and show the old
+// and new value. It returns no lines if the maps are the same.
+func (f *Formatter) DiffMaps(oldMap, newMap map[string]string, opts *DiffOptions) []string {
+	keys := []string{}
+	for key := range oldMap {
+		keys = append(keys, key)
+	}
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, key := range keys {
+		oldValue, inOld := oldMap[key]
+		newValue, inNew := newMap[key]
+		switch {
+		case !inOld:
+			lines = append(lines, f.colorize(fmt.Sprintf("+ %v: %v", key, newValue), insertColor))
+		case !inNew:
+			lines = append(lines, f.colorize(fmt.Sprintf("- %v: %v", key, oldValue), deleteColor))
+		case oldValue != newValue:
+			line := f.colorize(fmt.Sprintf("~ %v: ", key), changeColor)
+			if opts.Words {
+				edits := diff.Words(oldValue, newValue)
+				line += f.highlight(edits, diff.Delete, deleteColor) + " → " + f.highlight(edits, diff.Insert, insertColor)
+			} else {
+				line += f.colorize(oldValue, deleteColor) + " → " + f.colorize(newValue, insertColor)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func hunkHeader(hunk *diff.Hunk) string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines)
+}
+
+// split splits a block of changes into its deletions and insertions
+func split(block []diff.Edit) (deletes, inserts []diff.Edit) {
+	for _, edit := range block {
+		if edit.Op == diff.Delete {
+			deletes = append(deletes, edit)
+		} else {
+			inserts = append(inserts, edit)
+		}
+	}
+	return deletes, inserts
+}
+
+// partner returns the text of the i-th edit, or false if there isn't one
+func partner(edits []diff.Edit, i int) (string, bool) {
+	if i < len(edits) {
+		return edits[i].Text, true
+	}
+	return "", false
+}
+
+// changedLine renders a deleted or inserted line. If words is true and the line
+// has a partner line it replaced or was replaced by, the words that differ from
+// it are highlighted.
+func (f *Formatter) changedLine(prefix, text, other string, hasOther bool, op, color string, words bool) string {
+	if !words || !hasOther {
+		return f.colorize(prefix+text, color)
+	}
+	var edits []diff.Edit
+	if op == diff.Delete {
+		edits = diff.Words(text, other)
+	} else {
+		edits = diff.Words(other, text)
+	}
+	return f.colorize(prefix, color) + f.highlight(edits, op, color)
+}
+
+// highlight renders one side of a word diff, either the old words if op is
+// Delete or the new words if op is Insert, with the words that differ
+// highlighted
+func (f *Formatter) highlight(edits []diff.Edit, op, color string) string {
+	var b strings.Builder
+	run, changed := "", false
+	flush := func() {
+		if run == "" {
+			return
+		}
+		if changed {
+			b.WriteString(f.colorize(f.colorize(run, highlightStyle), color))
+		} else {
+			b.WriteString(f.colorize(run, color))
+		}
+		run = ""
+	}
+	for _, edit := range edits {
+		if edit.Op != diff.Equal && edit.Op != op {
+			continue
+		}
+		if isChanged := edit.Op == op; isChanged != changed {
+			flush()
+			changed = isChanged
+		}
+		run += edit.Text
+	}
+	flush()
+	return b.String()
+}
+
+// sideBySide renders hunks with the old lines on the left and the new lines on
+// the right
+func (f *Formatter) sideBySide(hunks []*diff.Hunk, opts *DiffOptions) []string {
+	last := hunks[len(hunks)-1]
+	numWidth := len(fmt.Sprint(last.OldStart + last.OldLines))
+	if n := len(fmt.Sprint(last.NewStart + last.NewLines)); n > numWidth {
+		numWidth = n
+	}
+	side := (opts.Width - len([]rune(sideSeparator))) / 2
+	textWidth := side - numWidth - 1
+	if textWidth < 1 {
+		textWidth = 1
+	}
+	side = numWidth + 1 + textWidth
+	s := &sideBySideDiff{f: f, numWidth: numWidth, textWidth: textWidth, words: opts.Words}
+	lines := []string{}
+	if opts.OldName != "" || opts.NewName != "" {
+		lines = append(lines, f.colorize(pad(truncate(opts.OldName, side), side)+sideSeparator+truncate(opts.NewName, side), "bold"))
+	}
+	for _, hunk := range hunks {
+		lines = append(lines, f.colorize(hunkHeader(hunk), hunkColor))
+		oldLine, newLine := hunk.OldStart, hunk.NewStart
+		if hunk.OldLines == 0 {
+			oldLine++
+		}
+		if hunk.NewLines == 0 {
+			newLine++
+		}
+		for _, block := range diff.Blocks(hunk.Edits) {
+			if block[0].Op == diff.Equal {
+				for _, edit := range block {
+					lines = append(lines, s.row(s.cell(oldLine, edit.Text, "", "", false), s.cell(newLine, edit.Text, "", "", false)))
+					oldLine++
+					newLine++
+				}
+				continue
+			}
+			deletes, inserts := split(block)
+			rows := len(deletes)
+			if len(inserts) > rows {
+				rows = len(inserts)
+			}
+			for i := 0; i < rows; i++ {
+				left, right := strings.Repeat(" ", side), ""
+				oldText, hasOld := partner(deletes, i)
+				newText, hasNew := partner(inserts, i)
+				if hasOld {
+					left = s.cell(oldLine, oldText, newText, diff.Delete, hasNew)
+					oldLine++
+				}
+				if hasNew {
+					right = s.cell(newLine, newText, oldText, diff.Insert, hasOld)
+					newLine++
+				}
+				lines = append(lines, s.row(left, right))
+			}
+		}
+	}
+	return lines
+}
+
+type sideBySideDiff struct {
+	f         *Formatter
+	numWidth  int
+	textWidth int
+	words     bool
+}
+
+// row joins the two sides of a row of a side by side diff, without padding at
+// the end of the row
+func (s *sideBySideDiff) row(left, right string) string {
+	return strings.TrimRight(left+sideSeparator+right, " ")
+}
+
+// cell renders one side of a row of a side by side diff, padded to the width
+// of the side. If op is empty the line is unchanged.
+func (s *sideBySideDiff) cell(number int, text, other, op string, hasOther bool) string {
+	num := fmt.Sprintf("%*d ", s.numWidth, number)
+	text, other = truncate(text, s.textWidth), truncate(other, s.textWidth)
+	width := s.numWidth + 1 + s.textWidth
+	switch op {
+	case diff.Delete:
+		return pad(s.f.changedLine(num, text, other, hasOther, op, deleteColor, s.words), width)
+	case diff.Insert:
+		return pad(s.f.changedLine(num, text, other, hasOther, op, insertColor, s.words), width)
+	default:
+		return pad(num+text, width)
+	}
+}
+
+// pad pads text with spaces to the passed width
+func pad(text string, width int) string {
+	if n := lenNoAnsi(text); n < width {
+		return text + strings.Repeat(" ", width-n)
+	}
+	return text
+}
@@ -0,0 +1,88 @@
+package formatter
+
+import "fmt"
+
+// tagColorer colors text by wrapping it in tags named after the color, so
+// colored output can be read in tests
+type tagColorer struct{}
+
+func (c tagColorer) Color(text, color string) (string, bool) {
+	return fmt.Sprintf("<%v>%v</%v>", color, text, color), true
+}
+
+const (
+	oldConfig = "name: web\nreplicas: 3\nimage: nginx:1.19\nport: 80\n"
+	newConfig = "name: web\nreplicas: 5\nimage: nginx:1.19\nport: 80\ndebug: true\n"
+)
+
+func (suite *FormatterSuite) TestDiff() {
+	suite.Formatter.colorer = nil
+	expected := []string{
+		"--- old",
+		"+++ new",
+		"@@ -1,4 +1,5 @@",
+		" name: web",
+		"-replicas: 3",
+		"+replicas: 5",
+		" image: nginx:1.19",
+		" port: 80",
+		"+debug: true",
+	}
+	actual := suite.Formatter.Diff(oldConfig, newConfig, &DiffOptions{Context: 3, OldName: "old", NewName: "new"})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestDiffWithLessContext() {
+	suite.Formatter.colorer = nil
+	expected := []string{
+		"@@ -2,1 +2,1 @@",
+		"-replicas: 3",
+		"+replicas: 5",
+		"@@ -4,0 +5,1 @@",
+		"+debug: true",
+	}
+	actual := suite.Formatter.Diff(oldConfig, newConfig, &DiffOptions{Context: 0})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestDiffWithNoChanges() {
+	suite.Empty(suite.Formatter.Diff(oldConfig, oldConfig, &DiffOptions{Context: 3}))
+}
+
+func (suite *FormatterSuite) TestDiffWithWords() {
+	suite.Formatter.colorer = tagColorer{}
+	expected := []string{
+		"<cyan>@@ -2,1 +2,1 @@</cyan>",
+		"<red>-</red><red>replicas: </red><red><reverse>3</reverse></red>",
+		"<green>+</green><green>replicas: </green><green><reverse>5</reverse></green>",
+	}
+	actual := suite.Formatter.Diff("name\nreplicas: 3\n", "name\nreplicas: 5\n", &DiffOptions{Words: true})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestDiffSideBySide() {
+	suite.Formatter.colorer = nil
+	expected := []string{
+		"old          │ new",
+		"@@ -1,4 +1,5 @@",
+		"1 name: web  │ 1 name: web",
+		"2 replicas:… │ 2 replicas:…",
+		"3 image: ng… │ 3 image: ng…",
+		"4 port: 80   │ 4 port: 80",
+		"             │ 5 debug: tr…",
+	}
+	actual := suite.Formatter.Diff(oldConfig, newConfig, &DiffOptions{Context: 3, SideBySide: true, Width: 28, OldName: "old", NewName: "new"})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestDiffMaps() {
+	suite.Formatter.colorer = tagColorer{}
+	oldMap := map[string]string{"name": "web", "replicas": "3", "region": "us-east-1"}
+	newMap := map[string]string{"name": "web", "replicas": "5", "debug": "true"}
+	expected := []string{
+		"<green>+ debug: true</green>",
+		"<red>- region: us-east-1</red>",
+		"<yellow>~ replicas: </yellow><red>3</red> → <green>5</green>",
+	}
+	suite.Equal(expected, suite.Formatter.DiffMaps(oldMap, newMap, &DiffOptions{}))
+}
@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	c "github.com/tomguerney/printer/internal/colorer"
 )
 
 // Formatter formats strings for simple and consistent output
 type Formatter struct {
 	TWOptions *TabwriterOptions
+	colorer   colorer
+}
+
+type colorer interface {
+	Color(text, color string) (string, bool)
 }
 
 // New returns a pointer to a new Formatter struct
@@ -22,6 +29,7 @@ func New() *Formatter {
 	}
 	return &Formatter{
 		defaultTabwriterOptions,
+		c.New(),
 	}
 }
 
@@ -33,7 +41,12 @@ type TabwriterOptions struct {
 
 // SetTabwriterOptions sets tabwriter options
 func (f *Formatter) SetTabwriterOptions(twOptions *TabwriterOptions) {
-	f.SetTabwriterOptions(twOptions)
+	f.TWOptions = twOptions
+}
+
+// TabwriterOptions returns the current tabwriter options
+func (f *Formatter) TabwriterOptions() *TabwriterOptions {
+	return f.TWOptions
 }
 
 // Text returns the passed text appended with a newline. If the text contains
@@ -58,9 +71,14 @@ func (f *Formatter) Text(i interface{}, a ...interface{}) string {
 // Tabulate returns a one-dimensional slice of strings, with each element formed
 // from a row of strings from the original 2D slice. Each row is spaced such
 // that when the slice is printed row by row, the element in each row appear
-// vertically aligned in equally-spaced columns
+// vertically aligned in equally-spaced columns.
+//
+// A cell containing newlines is a multi-line cell. Its row is expanded into as
+// many lines as its tallest cell, with shorter cells padded vertically so the
+// other columns stay aligned, and columns are sized by their longest line.
 func (f *Formatter) Tabulate(rows [][]string, headers ...string) []string {
 
+	rows = expandRows(rows)
 	widths := getColWidths(append(rows, headers), f.TWOptions.Minwidth)
 
 	if headers != nil && len(headers) > 0 {
@@ -83,6 +101,71 @@ func (f *Formatter) Tabulate(rows [][]string, headers ...string) []string {
 	return strRows
 }
 
+// expandRows expands each row containing a multi-line cell into one row per
+// line. Empty cells at the end of the continuation rows are dropped so they
+// aren't padded with trailing whitespace.
+func expandRows(rows [][]string) [][]string {
+	expanded := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		height := 1
+		cells := make([][]string, len(row))
+		for col, val := range row {
+			cells[col] = splitCell(val)
+			if len(cells[col]) > height {
+				height = len(cells[col])
+			}
+		}
+		if height == 1 {
+			expanded = append(expanded, row)
+			continue
+		}
+		for line := 0; line < height; line++ {
+			physical := make([]string, len(row))
+			for col, lines := range cells {
+				if line < len(lines) {
+					physical[col] = lines[line]
+				}
+			}
+			if line > 0 {
+				for len(physical) > 0 && physical[len(physical)-1] == "" {
+					physical = physical[:len(physical)-1]
+				}
+			}
+			expanded = append(expanded, physical)
+		}
+	}
+	return expanded
+}
+
+// splitCell splits a cell into its lines. A color span that crosses a newline
+// is reset at the end of the line and restored at the start of the next, so
+// color doesn't bleed into the padding or neighbouring cells.
+func splitCell(cell string) []string {
+	if !strings.Contains(cell, "\n") {
+		return []string{cell}
+	}
+	lines := strings.Split(strings.Replace(cell, "\r\n", "\n", -1), "\n")
+	var active []string
+	for i, line := range lines {
+		prefix := strings.Join(active, "")
+		for _, code := range ansiRegexp.FindAllString(line, -1) {
+			if !strings.HasSuffix(code, "m") {
+				continue
+			}
+			if code == ansiReset || code == "\u001b[m" {
+				active = nil
+			} else {
+				active = append(active, code)
+			}
+		}
+		lines[i] = prefix + line
+		if len(active) > 0 && i < len(lines)-1 {
+			lines[i] += ansiReset
+		}
+	}
+	return lines
+}
+
 func getColWidths(rows [][]string, minWidth int) map[int]int {
 	widths := make(map[int]int)
 	for _, row := range rows {
@@ -98,10 +181,24 @@ func getColWidths(rows [][]string, minWidth int) map[int]int {
 	return widths
 }
 
+var ansiRegexp = regexp.MustCompile("[\u001b\u009b][[()#;?]*(?:[0-9]{1,4}(?:;[0-9]{0,4})*)?[0-9A-ORZcf-nqry=><]")
+
+// lenNoAnsi returns the number of terminal columns the passed string occupies
+// once stripped of ANSI escape codes
 func lenNoAnsi(str string) int {
-	const ansi = "[\u001b\u009b][[()#;?]*(?:[0-9]{1,4}(?:;[0-9]{0,4})*)?[0-9A-ORZcf-nqry=><]"
-	var re = regexp.MustCompile(ansi)
-	return len(re.ReplaceAllString(str, ""))
+	width := 0
+	for _, r := range ansiRegexp.ReplaceAllString(str, "") {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+func (f *Formatter) colorize(text, color string) string {
+	if color == "" || f.colorer == nil {
+		return text
+	}
+	colored, _ := f.colorer.Color(text, color)
+	return colored
 }
 
 func padRows(rows [][]string, widths map[int]int, padding int, paddingChar byte) [][]string {
@@ -131,3 +228,8 @@ func createDivRow(colWidths map[int]int, minWidth int, divChar byte) []string {
 	}
 	return divRow
 }
+
+// StripAnsi returns the passed text with any ANSI escape codes removed
+func StripAnsi(text string) string {
+	return ansiRegexp.ReplaceAllString(text, "")
+}
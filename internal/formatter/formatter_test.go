@@ -3,12 +3,23 @@ package formatter
 import (
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type FormatterSuite struct {
 	suite.Suite
 	Formatter *Formatter
+	Colorer   *MockColorer
+}
+
+type MockColorer struct {
+	mock.Mock
+}
+
+func (m *MockColorer) Color(text, color string) (string, bool) {
+	args := m.Called(text, color)
+	return args.String(0), args.Bool(1)
 }
 
 func (suite *FormatterSuite) SetupTest() {
@@ -19,7 +30,8 @@ func (suite *FormatterSuite) SetupTest() {
 		Padchar:  ' ',
 		Divchar:  '-',
 	}
-	suite.Formatter = &Formatter{tabwriterOptions}
+	suite.Colorer = new(MockColorer)
+	suite.Formatter = &Formatter{TWOptions: tabwriterOptions, colorer: suite.Colorer}
 }
 
 func (suite *FormatterSuite) TestText() {
@@ -87,6 +99,37 @@ func (suite *FormatterSuite) TestTabulateWithMorePadding() {
 	suite.Equal(expected, actual)
 }
 
+func (suite *FormatterSuite) TestTabulateWithMultiLineCells() {
+	table := [][]string{
+		{"web", "12 Main St\nSpringfield", "up"},
+		{"db", "1 Side Rd", "panic: oops\ngoroutine 1\nmain.main()"},
+	}
+	headers := []string{"name", "address", "status"}
+	expected := []string{
+		"name    address        status",
+		"----    -----------    -----------",
+		"web     12 Main St     up",
+		"        Springfield",
+		"db      1 Side Rd      panic: oops",
+		"                       goroutine 1",
+		"                       main.main()",
+	}
+	actual := suite.Formatter.Tabulate(table, headers...)
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestTabulateWithColoredMultiLineCell() {
+	table := [][]string{
+		{"\u001b[31mfirst\nsecond\u001b[0m", "x"},
+	}
+	expected := []string{
+		"\u001b[31mfirst\u001b[0m     x",
+		"\u001b[31msecond\u001b[0m",
+	}
+	actual := suite.Formatter.Tabulate(table)
+	suite.Equal(expected, actual)
+}
+
 func TestFormatterSuite(t *testing.T) {
 	suite.Run(t, new(FormatterSuite))
 }
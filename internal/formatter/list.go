@@ -0,0 +1,97 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// List styles
+const (
+	Bullet   = "bullet"
+	Numbered = "numbered"
+	Checkbox = "checkbox"
+	Lettered = "lettered"
+)
+
+var bulletGlyphs = []string{"•", "◦", "▪"}
+
+// ListItem is a single item of a list. Children are rendered as a nested list
+// beneath the item.
+type ListItem struct {
+	Text     string
+	Checked  bool
+	Children []*ListItem
+}
+
+// ListOptions are the options used to render a list
+type ListOptions struct {
+	Style  string
+	Color  string
+	Indent int
+	Width  int
+}
+
+// List renders the passed items as a list in the style set in the passed
+// ListOptions and returns the lines of the list.
+//
+// Each item is prefixed with a glyph (a bullet, number, letter or checkbox)
+// which is colored with the Color option if it is set. Nested items are
+// indented by the Indent option. Items longer than the Width option are wrapped
+// with a hanging indent so that wrapped lines align with the start of the item
+// text. A Width of 0 or less disables wrapping.
+func (f *Formatter) List(items []*ListItem, opts *ListOptions) []string {
+	return f.listLevel(items, opts, 0)
+}
+
+func (f *Formatter) listLevel(items []*ListItem, opts *ListOptions, depth int) []string {
+	lines := []string{}
+	indent := strings.Repeat(" ", depth*opts.Indent)
+	for i, item := range items {
+		glyph := listGlyph(opts.Style, i, depth, item.Checked)
+		prefix := indent + f.colorize(glyph, opts.Color) + " "
+		hanging := indent + strings.Repeat(" ", lenNoAnsi(glyph)+1)
+		width := 0
+		if opts.Width > 0 {
+			width = opts.Width - lenNoAnsi(hanging)
+			if width < 1 {
+				width = 1
+			}
+		}
+		for j, line := range wrap(item.Text, &WrapOptions{Width: width}) {
+			if j == 0 {
+				lines = append(lines, prefix+line)
+			} else {
+				lines = append(lines, hanging+line)
+			}
+		}
+		lines = append(lines, f.listLevel(item.Children, opts, depth+1)...)
+	}
+	return lines
+}
+
+func listGlyph(style string, index, depth int, checked bool) string {
+	switch style {
+	case Numbered:
+		return fmt.Sprintf("%d.", index+1)
+	case Lettered:
+		return letters(index) + "."
+	case Checkbox:
+		if checked {
+			return "✓"
+		}
+		return "✗"
+	default:
+		return bulletGlyphs[depth%len(bulletGlyphs)]
+	}
+}
+
+// letters returns the spreadsheet-style letter sequence for the passed
+// zero-based index, i.e. a, b, ... z, aa, ab, ...
+func letters(index int) string {
+	s := ""
+	for index >= 0 {
+		s = string(rune('a'+index%26)) + s
+		index = index/26 - 1
+	}
+	return s
+}
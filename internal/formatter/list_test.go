@@ -0,0 +1,79 @@
+package formatter
+
+func (suite *FormatterSuite) TestBulletList() {
+	items := []*ListItem{
+		{Text: "first"},
+		{Text: "second", Children: []*ListItem{
+			{Text: "nested", Children: []*ListItem{{Text: "deeper"}}},
+		}},
+	}
+	expected := []string{
+		"• first",
+		"• second",
+		"  ◦ nested",
+		"    ▪ deeper",
+	}
+	actual := suite.Formatter.List(items, &ListOptions{Style: Bullet, Indent: 2})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestNumberedList() {
+	items := []*ListItem{{Text: "one"}, {Text: "two", Children: []*ListItem{{Text: "nested"}}}}
+	expected := []string{
+		"1. one",
+		"2. two",
+		"   1. nested",
+	}
+	actual := suite.Formatter.List(items, &ListOptions{Style: Numbered, Indent: 3})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestLetteredList() {
+	items := make([]*ListItem, 28)
+	for i := range items {
+		items[i] = &ListItem{Text: "item"}
+	}
+	actual := suite.Formatter.List(items, &ListOptions{Style: Lettered})
+	suite.Equal("a. item", actual[0])
+	suite.Equal("z. item", actual[25])
+	suite.Equal("aa. item", actual[26])
+	suite.Equal("ab. item", actual[27])
+}
+
+func (suite *FormatterSuite) TestCheckboxList() {
+	items := []*ListItem{{Text: "done", Checked: true}, {Text: "not done"}}
+	expected := []string{
+		"✓ done",
+		"✗ not done",
+	}
+	actual := suite.Formatter.List(items, &ListOptions{Style: Checkbox})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestListWithHangingIndent() {
+	items := []*ListItem{
+		{Text: "a long item that should wrap onto the next line"},
+		{Text: "short", Children: []*ListItem{{Text: "a nested item that wraps too"}}},
+	}
+	expected := []string{
+		"1. a long item that",
+		"   should wrap onto",
+		"   the next line",
+		"2. short",
+		"  1. a nested item",
+		"     that wraps too",
+	}
+	actual := suite.Formatter.List(items, &ListOptions{Style: Numbered, Indent: 2, Width: 20})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestListWithColoredGlyphs() {
+	suite.Colorer.On("Color", "•", "red").Return("red•", true)
+	items := []*ListItem{{Text: "first"}, {Text: "second"}}
+	expected := []string{
+		"red• first",
+		"red• second",
+	}
+	actual := suite.Formatter.List(items, &ListOptions{Color: "red"})
+	suite.Equal(expected, actual)
+}
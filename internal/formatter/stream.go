@@ -0,0 +1,144 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// StreamOptions are the options used to stream a table. SampleSize is the
+// number of rows measured to size the columns before any rows are written.
+// Widths are fixed column widths, which are used instead of measuring a sample.
+type StreamOptions struct {
+	SampleSize int
+	Widths     []int
+}
+
+// TableStream writes the rows of a table to an io.Writer as they arrive,
+// without needing every row in memory to size the columns
+type TableStream struct {
+	formatter  *Formatter
+	writer     io.Writer
+	headers    []string
+	widths     map[int]int
+	sample     [][]string
+	sampleSize int
+	started    bool
+}
+
+// NewTableStream returns a pointer to a new TableStream that writes rows to the
+// passed io.Writer, spaced as per the Formatter's tabwriter options.
+//
+// If fixed widths are set in the passed StreamOptions, rows are written as
+// soon as they arrive. Otherwise the first SampleSize rows are buffered and
+// measured to size the columns, and are written along with the headers once
+// the sample is full or the stream is flushed. If a later row is wider than
+// its column, the column grows and the headers are written again so the
+// columns stay aligned.
+func (f *Formatter) NewTableStream(w io.Writer, headers []string, opts *StreamOptions) *TableStream {
+	stream := &TableStream{
+		formatter:  f,
+		writer:     w,
+		headers:    headers,
+		widths:     map[int]int{},
+		sampleSize: opts.SampleSize,
+	}
+	if len(opts.Widths) > 0 {
+		for col, width := range opts.Widths {
+			stream.widths[col] = width
+		}
+		stream.sampleSize = 0
+	}
+	return stream
+}
+
+// Write writes a row to the stream
+func (s *TableStream) Write(row []string) error {
+	if !s.started {
+		s.sample = append(s.sample, row)
+		if len(s.sample) < s.sampleSize {
+			return nil
+		}
+		return s.Flush()
+	}
+	if s.grow(row) {
+		if err := s.writeHeaders(); err != nil {
+			return err
+		}
+	}
+	return s.writeRow(row)
+}
+
+// Flush writes the headers and any buffered sample rows. It should be called
+// once the last row has been written.
+func (s *TableStream) Flush() error {
+	if s.started {
+		return nil
+	}
+	s.started = true
+	s.grow(s.headers)
+	for _, row := range s.sample {
+		s.grow(row)
+	}
+	if err := s.writeHeaders(); err != nil {
+		return err
+	}
+	for _, row := range s.sample {
+		if err := s.writeRow(row); err != nil {
+			return err
+		}
+	}
+	s.sample = nil
+	return nil
+}
+
+// grow widens any column the passed row doesn't fit within. It returns true if
+// the columns need to be realigned, which is the case if any column but the
+// last one grows, since the last column isn't padded.
+func (s *TableStream) grow(row []string) bool {
+	realign := false
+	for col, cell := range row {
+		width, known := s.widths[col]
+		l := 0
+		for _, line := range splitCell(cell) {
+			if lenNoAnsi(line) > l {
+				l = lenNoAnsi(line)
+			}
+		}
+		if l < s.formatter.TWOptions.Minwidth {
+			l = s.formatter.TWOptions.Minwidth
+		}
+		if known && l <= width {
+			continue
+		}
+		if l > width {
+			s.widths[col] = l
+		}
+		if col < len(row)-1 {
+			realign = true
+		}
+	}
+	return realign
+}
+
+func (s *TableStream) writeHeaders() error {
+	if len(s.headers) == 0 {
+		return nil
+	}
+	divRow := createDivRow(s.widths, s.formatter.TWOptions.Minwidth, s.formatter.TWOptions.Divchar)
+	if err := s.writeRow(s.headers); err != nil {
+		return err
+	}
+	return s.writeRow(divRow)
+}
+
+func (s *TableStream) writeRow(row []string) error {
+	lines := expandRows([][]string{append([]string{}, row...)})
+	padded := padRows(lines, s.widths, s.formatter.TWOptions.Padding, s.formatter.TWOptions.Padchar)
+	for _, line := range padded {
+		if _, err := fmt.Fprintln(s.writer, strings.Join(line, "")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
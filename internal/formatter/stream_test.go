@@ -0,0 +1,71 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+)
+
+func (suite *FormatterSuite) TestTableStream() {
+	out := new(bytes.Buffer)
+	stream := suite.Formatter.NewTableStream(out, []string{"name", "status"}, &StreamOptions{SampleSize: 2})
+	suite.NoError(stream.Write([]string{"web", "running"}))
+	suite.Empty(out.String())
+	suite.NoError(stream.Write([]string{"database", "stopped"}))
+	suite.NoError(stream.Write([]string{"db", "running"}))
+	suite.NoError(stream.Flush())
+	expected := strings.Join([]string{
+		"name        status",
+		"--------    -------",
+		"web         running",
+		"database    stopped",
+		"db          running",
+		"",
+	}, "\n")
+	suite.Equal(expected, out.String())
+}
+
+func (suite *FormatterSuite) TestTableStreamFlushesPartialSample() {
+	out := new(bytes.Buffer)
+	stream := suite.Formatter.NewTableStream(out, []string{"name"}, &StreamOptions{SampleSize: 10})
+	suite.NoError(stream.Write([]string{"web"}))
+	suite.NoError(stream.Flush())
+	suite.Equal("name\n----\nweb\n", out.String())
+}
+
+func (suite *FormatterSuite) TestTableStreamReemitsHeadersWhenWidthsGrow() {
+	out := new(bytes.Buffer)
+	stream := suite.Formatter.NewTableStream(out, []string{"name", "status"}, &StreamOptions{SampleSize: 1})
+	suite.NoError(stream.Write([]string{"web", "running"}))
+	suite.NoError(stream.Write([]string{"database", "stopped"}))
+	suite.NoError(stream.Write([]string{"db", "a much longer status"}))
+	expected := strings.Join([]string{
+		"name    status",
+		"----    -------",
+		"web     running",
+		"name        status",
+		"--------    -------",
+		"database    stopped",
+		"db          a much longer status",
+		"",
+	}, "\n")
+	suite.Equal(expected, out.String())
+}
+
+func (suite *FormatterSuite) TestTableStreamWithFixedWidths() {
+	out := new(bytes.Buffer)
+	stream := suite.Formatter.NewTableStream(out, nil, &StreamOptions{SampleSize: 10, Widths: []int{6, 4}})
+	suite.NoError(stream.Write([]string{"web", "up"}))
+	suite.Equal("web       up\n", out.String())
+}
+
+func (suite *FormatterSuite) TestTableStreamWithMultiLineCells() {
+	out := new(bytes.Buffer)
+	stream := suite.Formatter.NewTableStream(out, nil, &StreamOptions{SampleSize: 1})
+	suite.NoError(stream.Write([]string{"web", "line one\nline two", "up"}))
+	expected := strings.Join([]string{
+		"web    line one    up",
+		"       line two",
+		"",
+	}, "\n")
+	suite.Equal(expected, out.String())
+}
@@ -0,0 +1,16 @@
+package formatter
+
+import "strings"
+
+// Style applies a color or style such as "bold", "dim" or "bgblue" to a whole
+// line. Any reset within the line, such as at the end of a colored cell, is
+// followed by the style again so it carries on to the end of the line. The
+// line is returned unchanged if the style isn't available.
+func (f *Formatter) Style(line, style string) string {
+	styled := f.colorize(line, style)
+	if styled == line || !strings.HasSuffix(styled, line+ansiReset) {
+		return styled
+	}
+	code := strings.TrimSuffix(styled, line+ansiReset)
+	return code + strings.Replace(line, ansiReset, ansiReset+code, -1) + ansiReset
+}
@@ -0,0 +1,14 @@
+package formatter
+
+func (suite *FormatterSuite) TestStyle() {
+	line := "web    \u001b[31mrunning\u001b[0m    5"
+	suite.Colorer.On("Color", line, "dim").Return("\u001b[2m"+line+"\u001b[0m", true)
+	expected := "\u001b[2mweb    \u001b[31mrunning\u001b[0m\u001b[2m    5\u001b[0m"
+	suite.Equal(expected, suite.Formatter.Style(line, "dim"))
+	suite.Equal(lenNoAnsi(line), lenNoAnsi(expected))
+}
+
+func (suite *FormatterSuite) TestStyleWithUnknownStyle() {
+	suite.Colorer.On("Color", "web", "sparkly").Return("web", false)
+	suite.Equal("web", suite.Formatter.Style("web", "sparkly"))
+}
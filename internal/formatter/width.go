@@ -0,0 +1,50 @@
+package formatter
+
+import "unicode"
+
+// runeWidth returns the number of terminal columns the passed rune occupies.
+// Combining marks and zero-width characters occupy no columns, East Asian wide
+// and fullwidth characters and most emoji occupy two, and everything else
+// occupies one.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK Radicals to CJK Symbols and Punctuation
+	{0x3041, 0x33FF},   // Hiragana to CJK Compatibility
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0xFE30, 0xFE4F},   // CJK Compatibility Forms
+	{0xFF00, 0xFF60},   // Fullwidth Forms
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs
+	{0x1F300, 0x1F64F}, // Miscellaneous Symbols and Pictographs, Emoticons
+	{0x1F900, 0x1F9FF}, // Supplemental Symbols and Pictographs
+	{0x20000, 0x3FFFD}, // CJK Unified Ideographs Extensions B onwards
+}
+
+func isWide(r rune) bool {
+	for _, wr := range wideRanges {
+		if r >= wr[0] && r <= wr[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Width returns the number of terminal columns the passed string occupies once
+// stripped of ANSI escape codes
+func Width(str string) int {
+	return lenNoAnsi(str)
+}
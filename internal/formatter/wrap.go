@@ -0,0 +1,143 @@
+package formatter
+
+import "strings"
+
+const ansiReset = "\u001b[0m"
+
+// WrapOptions are the options used to wrap text. Width is the number of
+// columns lines are wrapped at, Indent is the number of spaces the first line
+// is indented by, and Hanging is the number of spaces every subsequent line is
+// indented by. Both indents count towards the width, and negative indents are
+// treated as 0.
+type WrapOptions struct {
+	Width   int
+	Indent  int
+	Hanging int
+}
+
+// Wrap breaks the passed text into lines no wider than the Width option,
+// breaking on whitespace, and returns the lines. Existing newlines are
+// preserved, as are lines that already fit within the width. Words wider than
+// the width are broken across lines. A Width of 0 or less disables wrapping.
+//
+// Widths are measured in terminal columns, so ANSI escape codes occupy no
+// columns and wide runes occupy two. A color span that is broken across lines
+// is reset at the end of each line and restored at the start of the next,
+// including across existing newlines, so each line can be printed on its own
+// without color bleeding into any prefix or border.
+func (f *Formatter) Wrap(text string, opts *WrapOptions) []string {
+	return wrap(text, opts)
+}
+
+func wrap(text string, opts *WrapOptions) []string {
+	w := &wrapper{
+		width:   opts.Width,
+		indent:  strings.Repeat(" ", max(0, opts.Indent)),
+		hanging: strings.Repeat(" ", max(0, opts.Hanging)),
+	}
+	for _, paragraph := range strings.Split(text, "\n") {
+		w.paragraph(paragraph)
+	}
+	return w.lines
+}
+
+type wrapper struct {
+	width           int
+	indent, hanging string
+	lines           []string
+	line            strings.Builder
+	lineWidth       int
+	empty           bool
+	active          []string
+	reopen          bool
+}
+
+func (w *wrapper) paragraph(paragraph string) {
+	w.startLine()
+	available := w.width - w.lineWidth
+	if w.width <= 0 || lenNoAnsi(paragraph) <= available {
+		w.write(paragraph, lenNoAnsi(paragraph))
+		w.endLine()
+		return
+	}
+	for _, word := range strings.Fields(paragraph) {
+		width := lenNoAnsi(word)
+		if !w.empty && w.lineWidth+1+width > w.width {
+			w.endLine()
+			w.startLine()
+		}
+		if !w.empty {
+			w.write(" ", 1)
+		}
+		if w.lineWidth+width > w.width {
+			w.breakWord(word)
+			continue
+		}
+		w.write(word, width)
+	}
+	w.endLine()
+}
+
+// breakWord writes a word that is too wide to fit on a line, breaking it across
+// as many lines as it needs
+func (w *wrapper) breakWord(word string) {
+	for len(word) > 0 {
+		if loc := ansiRegexp.FindStringIndex(word); loc != nil && loc[0] == 0 {
+			w.write(word[:loc[1]], 0)
+			word = word[loc[1]:]
+			continue
+		}
+		r := []rune(word)[0]
+		if w.lineWidth+runeWidth(r) > w.width && !w.empty {
+			w.endLine()
+			w.startLine()
+		}
+		w.write(string(r), runeWidth(r))
+		word = word[len(string(r)):]
+	}
+}
+
+func (w *wrapper) startLine() {
+	if len(w.lines) == 0 {
+		w.line.WriteString(w.indent)
+		w.lineWidth = len(w.indent)
+	} else {
+		w.line.WriteString(w.hanging)
+		w.lineWidth = len(w.hanging)
+	}
+	if w.reopen {
+		w.line.WriteString(strings.Join(w.active, ""))
+	}
+	w.empty = true
+}
+
+func (w *wrapper) write(text string, width int) {
+	for _, code := range ansiRegexp.FindAllString(text, -1) {
+		if !strings.HasSuffix(code, "m") {
+			continue
+		}
+		if code == ansiReset || code == "\u001b[m" {
+			w.active = nil
+		} else {
+			w.active = append(w.active, code)
+		}
+	}
+	w.line.WriteString(text)
+	w.lineWidth += width
+	if width > 0 {
+		w.empty = false
+	}
+}
+
+// endLine finishes the current line. Any active color span is reset, to be
+// restored at the start of the next line, whether the line is broken
+// mid-paragraph or ends a paragraph.
+func (w *wrapper) endLine() {
+	w.reopen = len(w.active) > 0
+	if w.reopen {
+		w.line.WriteString(ansiReset)
+	}
+	w.lines = append(w.lines, w.line.String())
+	w.line.Reset()
+	w.lineWidth = 0
+}
@@ -0,0 +1,103 @@
+package formatter
+
+func (suite *FormatterSuite) TestWrap() {
+	text := "the quick brown fox jumps over the lazy dog"
+	expected := []string{
+		"the quick brown",
+		"fox jumps over",
+		"the lazy dog",
+	}
+	actual := suite.Formatter.Wrap(text, &WrapOptions{Width: 15})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestWrapWithoutWidth() {
+	text := "a line\n  that is not wrapped"
+	actual := suite.Formatter.Wrap(text, &WrapOptions{})
+	suite.Equal([]string{"a line", "  that is not wrapped"}, actual)
+}
+
+func (suite *FormatterSuite) TestWrapWithHangingIndent() {
+	text := "the quick brown fox jumps over the lazy dog"
+	expected := []string{
+		"- the quick brown",
+		"  fox jumps over",
+		"  the lazy dog",
+	}
+	actual := suite.Formatter.Wrap("- "+text, &WrapOptions{Width: 17, Hanging: 2})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestWrapWithIndent() {
+	expected := []string{
+		"    one two",
+		"  three four",
+	}
+	actual := suite.Formatter.Wrap("one two three four", &WrapOptions{Width: 12, Indent: 4, Hanging: 2})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestWrapWithNegativeIndent() {
+	actual := suite.Formatter.Wrap("one two three", &WrapOptions{Width: 8, Indent: -1, Hanging: -2})
+	suite.Equal([]string{"one two", "three"}, actual)
+}
+
+func (suite *FormatterSuite) TestWrapBreaksLongWords() {
+	expected := []string{
+		"a",
+		"abcde",
+		"fghij",
+		"b",
+	}
+	actual := suite.Formatter.Wrap("a abcdefghij b", &WrapOptions{Width: 5})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestWrapKeepsColorSpans() {
+	red := "\u001b[31m"
+	reset := "\u001b[0m"
+	text := "plain " + red + "red text that wraps" + reset + " plain"
+	expected := []string{
+		"plain " + red + "red" + reset,
+		red + "text that" + reset,
+		red + "wraps" + reset + " plain",
+	}
+	actual := suite.Formatter.Wrap(text, &WrapOptions{Width: 11})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestWrapKeepsColorSpansAcrossNewlines() {
+	red := "\u001b[31m"
+	reset := "\u001b[0m"
+	text := red + "red\nstill red" + reset + "\nplain"
+	expected := []string{
+		red + "red" + reset,
+		red + "still red" + reset,
+		"plain",
+	}
+	actual := suite.Formatter.Wrap(text, &WrapOptions{Width: 20, Hanging: 2})
+	suite.Equal([]string{"  " + red + "still red" + reset}, actual[1:2])
+	suite.Equal(expected, suite.Formatter.Wrap(text, &WrapOptions{Width: 20}))
+}
+
+func (suite *FormatterSuite) TestWrapWithWideRunes() {
+	expected := []string{
+		"日本語 の",
+		"テキスト",
+	}
+	actual := suite.Formatter.Wrap("日本語 の テキスト", &WrapOptions{Width: 9})
+	suite.Equal(expected, actual)
+}
+
+func (suite *FormatterSuite) TestTabulateWithWideRunes() {
+	table := [][]string{
+		{"日本", "a"},
+		{"abc", "b"},
+	}
+	expected := []string{
+		"日本    a",
+		"abc     b",
+	}
+	actual := suite.Formatter.Tabulate(table)
+	suite.Equal(expected, actual)
+}
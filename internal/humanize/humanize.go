@@ -0,0 +1,320 @@
+package humanize
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/printer/internal/dates"
+	"github.com/tomguerney/printer/internal/locale"
+)
+
+// Format types
+const (
+	Bytes        = "bytes"
+	Duration     = "duration"
+	Time         = "time"
+	RelativeTime = "relative"
+	Percent      = "percent"
+	Thousands    = "thousands"
+	Bool         = "bool"
+)
+
+// DefaultLayout is the layout times are formatted with if none is set
+const DefaultLayout = "2006-01-02 15:04"
+
+// now returns the current time, and is replaced in tests
+var now = time.Now
+
+// Format formats a value for humans. Type is one of the format types, or empty
+// for a format that only replaces empty values with the Empty placeholder.
+//
+// Bytes values are formatted in IEC units (KiB, MiB...) unless SI is true, in
+// which case SI units (kB, MB...) are used. Time values are formatted with
+// Layout in Location, which default to the locale's date layout and the local
+// time zone. Percent values are formatted with Precision decimal places, and
+// Thousands values are grouped with Separator, which defaults to the locale's
+// group separator. Bool values are formatted as True or False, which default to
+// "✓" and "✗".
+type Format struct {
+	Type      string
+	SI        bool
+	Layout    string
+	Location  *time.Location
+	Precision int
+	Separator string
+	True      string
+	False     string
+	Empty     string
+}
+
+// Validate returns an error if the Format's type isn't a known format type
+func (f *Format) Validate() error {
+	switch f.Type {
+	case "", Bytes, Duration, Time, RelativeTime, Percent, Thousands, Bool:
+		return nil
+	default:
+		return fmt.Errorf("unknown value format %v", f.Type)
+	}
+}
+
+// Apply formats the passed value in the default locale. An empty value is
+// replaced with the Empty placeholder. It returns an error if the value can't
+// be parsed as the Format's type.
+func (f *Format) Apply(value string) (string, error) {
+	return f.ApplyLocale(value, nil)
+}
+
+// ApplyLocale formats the passed value in the passed locale, or the default
+// locale if nil, using its decimal and group separators and date layout. An
+// empty value is replaced with the Empty placeholder. It returns an error if
+// the value can't be parsed as the Format's type.
+func (f *Format) ApplyLocale(value string, l *locale.Locale) (string, error) {
+	if l == nil {
+		l = locale.Default
+	}
+	if strings.TrimSpace(value) == "" {
+		if f.Empty != "" {
+			return f.Empty, nil
+		}
+		return value, nil
+	}
+	switch f.Type {
+	case Bytes:
+		n, err := parseNumber(value)
+		if err != nil {
+			return value, err
+		}
+		return l.Decimals(FormatBytes(n, f.SI)), nil
+	case Duration:
+		d, err := parseDuration(value)
+		if err != nil {
+			return value, err
+		}
+		return FormatDuration(d), nil
+	case Time, RelativeTime:
+		t, err := parseTime(value)
+		if err != nil {
+			return value, err
+		}
+		if f.Type == RelativeTime {
+			return FormatRelativeTime(t, now()), nil
+		}
+		layout := f.Layout
+		if layout == "" {
+			layout = l.DateLayout
+		}
+		return FormatTime(t, layout, f.Location), nil
+	case Percent:
+		n, err := parseNumber(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+		if err != nil {
+			return value, err
+		}
+		return l.Decimals(FormatPercent(n, f.Precision)), nil
+	case Thousands:
+		if _, err := parseNumber(value); err != nil || strings.Contains(value, ",") {
+			return value, fmt.Errorf("unable to parse %q as a number", value)
+		}
+		return l.Number(strings.TrimSpace(value), f.Separator), nil
+	case Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return value, err
+		}
+		return FormatBool(b, f.True, f.False), nil
+	case "":
+		return value, nil
+	default:
+		return value, f.Validate()
+	}
+}
+
+var (
+	iecUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+// FormatBytes formats a number of bytes in IEC units, or SI units if si is
+// true, e.g. "1.5 KiB" or "1.5 kB"
+func FormatBytes(n float64, si bool) string {
+	base, units := 1024.0, iecUnits
+	if si {
+		base, units = 1000.0, siUnits
+	}
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	unit := 0
+	for n >= base && unit < len(units)-1 {
+		n /= base
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%s%v %s", sign, math.Round(n), units[unit])
+	}
+	return fmt.Sprintf("%s%s %s", sign, trimZero(strconv.FormatFloat(n, 'f', 1, 64)), units[unit])
+}
+
+// FormatDuration formats a duration in its two largest units, e.g. "3d4h",
+// "2m30s" or "350ms"
+func FormatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	if d < time.Second {
+		return sign + fmt.Sprintf("%dms", d/time.Millisecond)
+	}
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	parts := []string{}
+	for _, unit := range units {
+		n := d / unit.size
+		d -= n * unit.size
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.name))
+		} else if len(parts) > 0 {
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return sign + strings.Join(parts, "")
+}
+
+// FormatTime formats a time with the passed layout in the passed location,
+// which default to DefaultLayout and the local time zone
+func FormatTime(t time.Time, layout string, loc *time.Location) string {
+	if layout == "" {
+		layout = DefaultLayout
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return t.In(loc).Format(layout)
+}
+
+// FormatRelativeTime formats a time relative to now, e.g. "3 minutes ago" or
+// "in 2 hours"
+func FormatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Second {
+		return "just now"
+	}
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{365 * 24 * time.Hour, "year"},
+		{30 * 24 * time.Hour, "month"},
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	}
+	text := ""
+	for _, unit := range units {
+		if n := d / unit.size; n > 0 {
+			text = fmt.Sprintf("%d %s", n, unit.name)
+			if n > 1 {
+				text += "s"
+			}
+			break
+		}
+	}
+	if future {
+		return "in " + text
+	}
+	return text + " ago"
+}
+
+// FormatPercent formats a percentage with the passed number of decimal places,
+// e.g. "45.7%"
+func FormatPercent(n float64, precision int) string {
+	return strconv.FormatFloat(n, 'f', precision, 64) + "%"
+}
+
+// FormatThousands groups the digits of the integer part of a number with the
+// passed separator, which defaults to ",", e.g. "1,234,567.89". It returns an
+// error if the value isn't a number.
+func FormatThousands(value, separator string) (string, error) {
+	return (&Format{Type: Thousands, Separator: separator}).Apply(value)
+}
+
+// FormatBool formats a boolean as the passed true or false text, which default
+// to "✓" and "✗"
+func FormatBool(b bool, trueText, falseText string) string {
+	if b {
+		if trueText == "" {
+			return "✓"
+		}
+		return trueText
+	}
+	if falseText == "" {
+		return "✗"
+	}
+	return falseText
+}
+
+func trimZero(s string) string {
+	return strings.TrimSuffix(s, ".0")
+}
+
+func parseNumber(value string) (float64, error) {
+	value = strings.Replace(strings.TrimSpace(value), ",", "", -1)
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as a number", value)
+	}
+	return n, nil
+}
+
+// parseDuration parses a duration such as "1h30m", or a number of seconds
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil {
+		return d, nil
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(n * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("unable to parse %q as a duration", value)
+}
+
+// parseTime parses a date or time in any of the layouts understood by the
+// date comparator, or a Unix timestamp in seconds
+func parseTime(value string) (time.Time, error) {
+	if t, ok := dates.Parse(value); ok {
+		return t, nil
+	}
+	if n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+		return time.Unix(n, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unable to parse %q as a time", value)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "t", "yes", "y", "on", "1":
+		return true, nil
+	case "false", "f", "no", "n", "off", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unable to parse %q as a boolean", value)
+	}
+}
@@ -0,0 +1,134 @@
+package humanize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/tomguerney/printer/internal/locale"
+)
+
+type HumanizeSuite struct {
+	suite.Suite
+}
+
+func (suite *HumanizeSuite) SetupTest() {
+	now = func() time.Time { return time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC) }
+}
+
+func (suite *HumanizeSuite) TearDownTest() {
+	now = time.Now
+}
+
+func (suite *HumanizeSuite) TestFormatBytes() {
+	suite.Equal("512 B", FormatBytes(512, false))
+	suite.Equal("1.5 KiB", FormatBytes(1536, false))
+	suite.Equal("1 MiB", FormatBytes(1048576, false))
+	suite.Equal("1.5 kB", FormatBytes(1500, true))
+	suite.Equal("-2 GB", FormatBytes(-2e9, true))
+}
+
+func (suite *HumanizeSuite) TestFormatDuration() {
+	suite.Equal("350ms", FormatDuration(350*time.Millisecond))
+	suite.Equal("45s", FormatDuration(45*time.Second))
+	suite.Equal("2m30s", FormatDuration(150*time.Second))
+	suite.Equal("1h", FormatDuration(time.Hour+5*time.Second))
+	suite.Equal("3d4h", FormatDuration(76*time.Hour+10*time.Minute))
+	suite.Equal("-5m", FormatDuration(-5*time.Minute))
+}
+
+func (suite *HumanizeSuite) TestFormatTime() {
+	t := time.Date(2021, 3, 10, 12, 30, 0, 0, time.UTC)
+	loc := time.FixedZone("AEDT", 11*60*60)
+	suite.Equal("2021-03-10 23:30", FormatTime(t, "", loc))
+	suite.Equal("10 Mar 21", FormatTime(t, "02 Jan 06", time.UTC))
+}
+
+func (suite *HumanizeSuite) TestFormatRelativeTime() {
+	n := now()
+	suite.Equal("just now", FormatRelativeTime(n, n))
+	suite.Equal("1 minute ago", FormatRelativeTime(n.Add(-90*time.Second), n))
+	suite.Equal("3 days ago", FormatRelativeTime(n.Add(-72*time.Hour), n))
+	suite.Equal("in 2 hours", FormatRelativeTime(n.Add(2*time.Hour), n))
+}
+
+func (suite *HumanizeSuite) TestFormatThousands() {
+	var tests = []struct {
+		value, expected string
+	}{
+		{"123", "123"},
+		{"1234", "1,234"},
+		{"1234567.891", "1,234,567.891"},
+		{"-1234567", "-1,234,567"},
+	}
+	for _, tt := range tests {
+		actual, err := FormatThousands(tt.value, "")
+		suite.NoError(err)
+		suite.Equal(tt.expected, actual)
+	}
+	actual, err := FormatThousands("1234567", ".")
+	suite.NoError(err)
+	suite.Equal("1.234.567", actual)
+	_, err = FormatThousands("lots", "")
+	suite.Error(err)
+}
+
+func (suite *HumanizeSuite) TestApply() {
+	var tests = []struct {
+		format          *Format
+		value, expected string
+	}{
+		{&Format{Type: Bytes}, "2048", "2 KiB"},
+		{&Format{Type: Duration}, "90", "1m30s"},
+		{&Format{Type: Duration}, "1h30m", "1h30m"},
+		{&Format{Type: Time, Location: time.UTC}, "2021-03-09T08:15:00Z", "2021-03-09 08:15"},
+		{&Format{Type: RelativeTime}, "2021-03-10T11:55:00Z", "5 minutes ago"},
+		{&Format{Type: Percent, Precision: 1}, "45.678%", "45.7%"},
+		{&Format{Type: Thousands}, "1234", "1,234"},
+		{&Format{Type: Bool}, "true", "✓"},
+		{&Format{Type: Bool, True: "yes", False: "no"}, "false", "no"},
+		{&Format{Type: Bytes, Empty: "-"}, "", "-"},
+		{&Format{Empty: "n/a"}, "", "n/a"},
+		{&Format{Empty: "n/a"}, "value", "value"},
+	}
+	for _, tt := range tests {
+		actual, err := tt.format.Apply(tt.value)
+		suite.NoError(err)
+		suite.Equal(tt.expected, actual)
+	}
+}
+
+func (suite *HumanizeSuite) TestApplyLocale() {
+	de, err := locale.Lookup("de")
+	suite.NoError(err)
+	var tests = []struct {
+		format          *Format
+		value, expected string
+	}{
+		{&Format{Type: Bytes}, "1536", "1,5 KiB"},
+		{&Format{Type: Percent, Precision: 1}, "45.678", "45,7%"},
+		{&Format{Type: Thousands}, "1234567.5", "1.234.567,5"},
+		{&Format{Type: Thousands, Separator: " "}, "1234567", "1 234 567"},
+		{&Format{Type: Time, Location: time.UTC}, "2021-03-09T08:15:00Z", "09.03.2021 08:15"},
+	}
+	for _, tt := range tests {
+		actual, err := tt.format.ApplyLocale(tt.value, de)
+		suite.NoError(err)
+		suite.Equal(tt.expected, actual)
+	}
+}
+
+func (suite *HumanizeSuite) TestApplyWithInvalidValue() {
+	actual, err := (&Format{Type: Bytes}).Apply("lots")
+	suite.Error(err)
+	suite.Equal("lots", actual)
+}
+
+func (suite *HumanizeSuite) TestValidate() {
+	suite.NoError((&Format{Type: Bytes}).Validate())
+	suite.EqualError((&Format{Type: "sparkly"}).Validate(), "unknown value format sparkly")
+}
+
+func TestHumanizeSuite(t *testing.T) {
+	suite.Run(t, new(HumanizeSuite))
+}
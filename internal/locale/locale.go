@@ -0,0 +1,182 @@
+package locale
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Plural categories
+const (
+	One   = "one"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// Locale is the data used to format values for a locale. Decimal is the
+// decimal separator, Group is the separator between groups of thousands, and
+// DateLayout is the layout times are formatted with.
+type Locale struct {
+	Tag        string
+	Decimal    string
+	Group      string
+	DateLayout string
+	plural     func(n float64) string
+}
+
+// Default is the locale used when none is set. It formats numbers as in
+// English and times in ISO 8601 order.
+var Default = &Locale{
+	Tag:        "en",
+	Decimal:    ".",
+	Group:      ",",
+	DateLayout: "2006-01-02 15:04",
+	plural:     pluralOne,
+}
+
+var locales = map[string]*Locale{
+	"en":    Default,
+	"en-us": {Decimal: ".", Group: ",", DateLayout: "01/02/2006 3:04 PM", plural: pluralOne},
+	"en-gb": {Decimal: ".", Group: ",", DateLayout: "02/01/2006 15:04", plural: pluralOne},
+	"de":    {Decimal: ",", Group: ".", DateLayout: "02.01.2006 15:04", plural: pluralOne},
+	"nl":    {Decimal: ",", Group: ".", DateLayout: "02-01-2006 15:04", plural: pluralOne},
+	"es":    {Decimal: ",", Group: ".", DateLayout: "02/01/2006 15:04", plural: pluralOne},
+	"it":    {Decimal: ",", Group: ".", DateLayout: "02/01/2006 15:04", plural: pluralOne},
+	"pt":    {Decimal: ",", Group: ".", DateLayout: "02/01/2006 15:04", plural: pluralOne},
+	"pt-br": {Decimal: ",", Group: ".", DateLayout: "02/01/2006 15:04", plural: pluralZeroOne},
+	"fr":    {Decimal: ",", Group: " ", DateLayout: "02/01/2006 15:04", plural: pluralZeroOne},
+	"ru":    {Decimal: ",", Group: " ", DateLayout: "02.01.2006 15:04", plural: pluralSlavic(false)},
+	"uk":    {Decimal: ",", Group: " ", DateLayout: "02.01.2006 15:04", plural: pluralSlavic(false)},
+	"pl":    {Decimal: ",", Group: " ", DateLayout: "02.01.2006 15:04", plural: pluralSlavic(true)},
+	"ja":    {Decimal: ".", Group: ",", DateLayout: "2006/01/02 15:04", plural: pluralNone},
+	"zh":    {Decimal: ".", Group: ",", DateLayout: "2006/01/02 15:04", plural: pluralNone},
+	"ko":    {Decimal: ".", Group: ",", DateLayout: "2006. 01. 02. 15:04", plural: pluralNone},
+}
+
+// Lookup returns the locale with the passed tag, such as "de", "pt-BR" or
+// "en_GB.UTF-8". A tag without locale data of its own uses the data of its
+// language. It returns an error if there's no data for the tag's language.
+func Lookup(tag string) (*Locale, error) {
+	normalized := Normalize(tag)
+	data, ok := locales[strings.ToLower(normalized)]
+	if !ok {
+		data, ok = locales[Language(normalized)]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown locale %v", tag)
+	}
+	l := *data
+	l.Tag = normalized
+	return &l, nil
+}
+
+// Normalize normalizes a locale tag such as "en_GB.UTF-8" to the form "en-GB"
+func Normalize(tag string) string {
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	parts := strings.Split(strings.Replace(tag, "_", "-", -1), "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		if len(parts[i]) == 2 {
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Language returns the language of a locale tag, e.g. "pt" for "pt-BR"
+func Language(tag string) string {
+	return strings.ToLower(strings.SplitN(Normalize(tag), "-", 2)[0])
+}
+
+// Candidates returns the tags to look for localized data for, in order of
+// preference: the locale's tag, then its language
+func (l *Locale) Candidates() []string {
+	if language := Language(l.Tag); language != l.Tag {
+		return []string{l.Tag, language}
+	}
+	return []string{l.Tag}
+}
+
+// Plural returns the plural category of the passed number, which is one of
+// One, Few, Many or Other
+func (l *Locale) Plural(n float64) string {
+	if l.plural == nil {
+		return Other
+	}
+	return l.plural(math.Abs(n))
+}
+
+// Number localizes a number formatted with a "." decimal separator and no
+// grouping, such as "1234567.89", grouping its thousands and replacing its
+// decimal separator. The group separator is the locale's unless one is passed.
+func (l *Locale) Number(number, group string) string {
+	if group == "" {
+		group = l.Group
+	}
+	sign := ""
+	if strings.HasPrefix(number, "-") || strings.HasPrefix(number, "+") {
+		sign, number = number[:1], number[1:]
+	}
+	integer, fraction := number, ""
+	if i := strings.IndexAny(number, ".eE"); i >= 0 {
+		integer, fraction = number[:i], number[i:]
+	}
+	if strings.HasPrefix(fraction, ".") {
+		fraction = l.Decimal + fraction[1:]
+	}
+	var b strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + fraction
+}
+
+// Decimals replaces the "." decimal separator of a formatted number with the
+// locale's decimal separator, without grouping its thousands
+func (l *Locale) Decimals(number string) string {
+	return strings.Replace(number, ".", l.Decimal, 1)
+}
+
+func pluralOne(n float64) string {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+func pluralZeroOne(n float64) string {
+	if n < 2 {
+		return One
+	}
+	return Other
+}
+
+func pluralNone(n float64) string {
+	return Other
+}
+
+// pluralSlavic returns the plural rule of Russian and Ukrainian, or of Polish,
+// in which only 1 itself is in the One category
+func pluralSlavic(polish bool) func(n float64) string {
+	return func(n float64) string {
+		if n != math.Trunc(n) {
+			return Other
+		}
+		i := int64(n)
+		mod10, mod100 := i%10, i%100
+		switch {
+		case polish && i == 1, !polish && mod10 == 1 && mod100 != 11:
+			return One
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return Few
+		default:
+			return Many
+		}
+	}
+}
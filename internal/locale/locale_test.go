@@ -0,0 +1,87 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LocaleSuite struct {
+	suite.Suite
+}
+
+func (suite *LocaleSuite) TestLookup() {
+	var tests = []struct {
+		tag, expected, decimal string
+	}{
+		{"de", "de", ","},
+		{"de_AT.UTF-8", "de-AT", ","},
+		{"pt-br", "pt-BR", ","},
+		{"EN-gb", "en-GB", "."},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.tag, func() {
+			l, err := Lookup(tt.tag)
+			suite.NoError(err)
+			suite.Equal(tt.expected, l.Tag)
+			suite.Equal(tt.decimal, l.Decimal)
+		})
+	}
+	_, err := Lookup("xx")
+	suite.EqualError(err, "unknown locale xx")
+}
+
+func (suite *LocaleSuite) TestLookupDoesNotModifyData() {
+	l, _ := Lookup("en-AU")
+	suite.Equal("en-AU", l.Tag)
+	suite.Equal("en", Default.Tag)
+}
+
+func (suite *LocaleSuite) TestCandidates() {
+	l, _ := Lookup("de-AT")
+	suite.Equal([]string{"de-AT", "de"}, l.Candidates())
+	suite.Equal([]string{"en"}, Default.Candidates())
+}
+
+func (suite *LocaleSuite) TestNumber() {
+	de, _ := Lookup("de")
+	suite.Equal("1.234.567,89", de.Number("1234567.89", ""))
+	suite.Equal("-1'234", de.Number("-1234", "'"))
+	suite.Equal("123", Default.Number("123", ""))
+	suite.Equal("12,5", de.Decimals("12.5"))
+}
+
+func (suite *LocaleSuite) TestPlural() {
+	var tests = []struct {
+		tag      string
+		n        float64
+		expected string
+	}{
+		{"en", 1, One},
+		{"en", 0, Other},
+		{"en", 2, Other},
+		{"fr", 0, One},
+		{"fr", 1.5, One},
+		{"fr", 2, Other},
+		{"ru", 1, One},
+		{"ru", 21, One},
+		{"ru", 11, Many},
+		{"ru", 3, Few},
+		{"ru", 13, Many},
+		{"ru", 5, Many},
+		{"ru", 1.5, Other},
+		{"pl", 1, One},
+		{"pl", 21, Many},
+		{"pl", 22, Few},
+		{"ja", 1, Other},
+	}
+	for _, tt := range tests {
+		l, err := Lookup(tt.tag)
+		suite.NoError(err)
+		suite.Equal(tt.expected, l.Plural(tt.n), "%v %v", tt.tag, tt.n)
+	}
+}
+
+func TestLocaleSuite(t *testing.T) {
+	suite.Run(t, new(LocaleSuite))
+}
@@ -0,0 +1,103 @@
+package pager
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const prompt = "-- More -- (Enter for the next page, q to quit)"
+
+// Pager pages text through the external pager set in the PAGER environment
+// variable, falling back to "less -R", and falling back to a simple built-in
+// pager if neither is available.
+type Pager struct {
+	Out      io.Writer
+	In       io.Reader
+	Height   int
+	getenv   func(string) string
+	lookPath func(string) (string, error)
+}
+
+// New returns a pointer to a new Pager that pages to the passed io.Writer,
+// reads keypresses for the built-in pager from the passed io.Reader, and shows
+// pages of the passed height.
+func New(out io.Writer, in io.Reader, height int) *Pager {
+	return &Pager{
+		Out:      out,
+		In:       in,
+		Height:   height,
+		getenv:   os.Getenv,
+		lookPath: exec.LookPath,
+	}
+}
+
+// Page pages the passed text. Colors are passed through to the external pager.
+func (p *Pager) Page(text string) error {
+	for _, args := range p.commands() {
+		path, err := p.lookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		cmd.Stdout = p.Out
+		cmd.Stderr = os.Stderr
+		cmd.Env = os.Environ()
+		if p.getenv("LESS") == "" {
+			cmd.Env = append(cmd.Env, "LESS=R")
+		}
+		if err := cmd.Start(); err != nil {
+			continue
+		}
+		// The pager exits with a non-zero status if the user quits before the
+		// end of the text, which isn't an error
+		_ = cmd.Wait()
+		return nil
+	}
+	return p.builtin(text)
+}
+
+func (p *Pager) commands() [][]string {
+	commands := [][]string{}
+	if args := strings.Fields(p.getenv("PAGER")); len(args) > 0 {
+		commands = append(commands, args)
+	}
+	return append(commands, []string{"less", "-R"})
+}
+
+// builtin writes the passed text a page at a time, waiting for the user to
+// press Enter between pages. Entering "q" stops paging.
+func (p *Pager) builtin(text string) error {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	pageSize := p.Height - 1
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	reader := bufio.NewReader(p.In)
+	for start := 0; start < len(lines); start += pageSize {
+		end := start + pageSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		for _, line := range lines[start:end] {
+			if _, err := fmt.Fprintln(p.Out, line); err != nil {
+				return err
+			}
+		}
+		if end == len(lines) {
+			break
+		}
+		fmt.Fprint(p.Out, prompt)
+		input, err := reader.ReadString('\n')
+		// Move back up to the prompt line and clear it
+		fmt.Fprint(p.Out, "\r\u001b[1A\u001b[2K")
+		if err != nil || strings.TrimSpace(input) == "q" {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package pager
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PagerSuite struct {
+	suite.Suite
+	Out   *bytes.Buffer
+	Pager *Pager
+}
+
+func (suite *PagerSuite) SetupTest() {
+	suite.Out = new(bytes.Buffer)
+	suite.Pager = &Pager{
+		Out:      suite.Out,
+		In:       strings.NewReader(""),
+		Height:   3,
+		getenv:   func(string) string { return "" },
+		lookPath: func(string) (string, error) { return "", errors.New("not found") },
+	}
+}
+
+func (suite *PagerSuite) TestCommandsWithoutPagerEnv() {
+	suite.Equal([][]string{{"less", "-R"}}, suite.Pager.commands())
+}
+
+func (suite *PagerSuite) TestCommandsWithPagerEnv() {
+	suite.Pager.getenv = func(key string) string {
+		if key == "PAGER" {
+			return "most -s"
+		}
+		return ""
+	}
+	suite.Equal([][]string{{"most", "-s"}, {"less", "-R"}}, suite.Pager.commands())
+}
+
+func (suite *PagerSuite) TestBuiltinWhenNoPagerFound() {
+	suite.Pager.In = strings.NewReader("\n\n")
+	err := suite.Pager.Page("1\n2\n3\n4\n5\n")
+	suite.NoError(err)
+	expected := "1\n2\n" + prompt + "\r\u001b[1A\u001b[2K" +
+		"3\n4\n" + prompt + "\r\u001b[1A\u001b[2K" +
+		"5\n"
+	suite.Equal(expected, suite.Out.String())
+}
+
+func (suite *PagerSuite) TestBuiltinQuit() {
+	suite.Pager.In = strings.NewReader("q\n")
+	err := suite.Pager.Page("1\n2\n3\n4\n5")
+	suite.NoError(err)
+	suite.Equal("1\n2\n"+prompt+"\r\u001b[1A\u001b[2K", suite.Out.String())
+}
+
+func (suite *PagerSuite) TestBuiltinStopsAtEndOfInput() {
+	err := suite.Pager.Page("1\n2\n3")
+	suite.NoError(err)
+	suite.Equal("1\n2\n"+prompt+"\r\u001b[1A\u001b[2K", suite.Out.String())
+}
+
+func (suite *PagerSuite) TestExternalPager() {
+	suite.Pager.lookPath = func(file string) (string, error) {
+		if file == "less" {
+			return "", errors.New("not found")
+		}
+		return "/bin/cat", nil
+	}
+	suite.Pager.getenv = func(key string) string {
+		if key == "PAGER" {
+			return "cat"
+		}
+		return ""
+	}
+	err := suite.Pager.Page("paged\ntext\n")
+	suite.NoError(err)
+	suite.Equal("paged\ntext\n", suite.Out.String())
+}
+
+func TestPagerSuite(t *testing.T) {
+	suite.Run(t, new(PagerSuite))
+}
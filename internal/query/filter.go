@@ -0,0 +1,260 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Predicate reports whether a row should be kept
+type Predicate func(row map[string]string) bool
+
+// Filter returns the rows for which every passed Predicate returns true
+func Filter(rows []map[string]string, predicates ...Predicate) []map[string]string {
+	filtered := []map[string]string{}
+	for _, i := range Select(rows, predicates...) {
+		filtered = append(filtered, rows[i])
+	}
+	return filtered
+}
+
+// Select returns the indices of the rows for which every passed Predicate
+// returns true, in order
+func Select(rows []map[string]string, predicates ...Predicate) []int {
+	selected := []int{}
+	for i, row := range rows {
+		if matches(row, predicates) {
+			selected = append(selected, i)
+		}
+	}
+	return selected
+}
+
+func matches(row map[string]string, predicates []Predicate) bool {
+	for _, predicate := range predicates {
+		if !predicate(row) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses a filter expression into a Predicate. It returns an error if the
+// expression is invalid.
+//
+// An expression is made up of comparisons of a column with a value, such as
+// `status == running` or `cpu > 50`, which may be combined with "&&" and "||"
+// and grouped with parentheses. Values containing spaces can be quoted with
+// single or double quotes. The operators are "==", "!=", ">", ">=", "<" and
+// "<=", which compare numerically if both sides are numbers and as strings
+// otherwise, and "~" and "!~", which match and don't match a regular
+// expression. A value that isn't a number never matches an ordering comparison
+// with a number.
+func Parse(expression string) (Predicate, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse filter expression %q: %v", expression, err)
+	}
+	p := &parser{tokens: tokens}
+	predicate, err := p.or()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse filter expression %q: %v", expression, err)
+	}
+	return predicate, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+var operators = []string{"&&", "||", "==", "!=", ">=", "<=", "!~", ">", "<", "~", "(", ")"}
+
+func tokenize(expression string) ([]token, error) {
+	tokens := []token{}
+	s := expression
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return tokens, nil
+		}
+		if s[0] == '"' || s[0] == '\'' {
+			end := strings.IndexByte(s[1:], s[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			tokens = append(tokens, token{text: s[1 : end+1], quoted: true})
+			s = s[end+2:]
+			continue
+		}
+		if op := operatorPrefix(s); op != "" {
+			tokens = append(tokens, token{text: op})
+			s = s[len(op):]
+			continue
+		}
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || operatorPrefix(string(r)) != "" || r == '!' || r == '=' || r == '&' || r == '|'
+		})
+		if end == 0 {
+			return nil, fmt.Errorf("unexpected %q", s[:1])
+		}
+		if end < 0 {
+			end = len(s)
+		}
+		tokens = append(tokens, token{text: s[:end]})
+		s = s[end:]
+	}
+}
+
+func operatorPrefix(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) next() (token, error) {
+	t, ok := p.peek()
+	if !ok {
+		return token{}, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+	return t, nil
+}
+
+func (p *parser) isOperator(op string) bool {
+	t, ok := p.peek()
+	return ok && !t.quoted && t.text == op
+}
+
+func (p *parser) or() (Predicate, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.pos++
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(row map[string]string) bool { return l(row) || right(row) }
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Predicate, error) {
+	left, err := p.comparison()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.pos++
+		right, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(row map[string]string) bool { return l(row) && right(row) }
+	}
+	return left, nil
+}
+
+func (p *parser) comparison() (Predicate, error) {
+	if p.isOperator("(") {
+		p.pos++
+		predicate, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOperator(")") {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return predicate, nil
+	}
+	column, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	return compare(column.text, op.text, value.text)
+}
+
+func compare(column, op, value string) (Predicate, error) {
+	switch op {
+	case "~", "!~":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q", value)
+		}
+		match := op == "~"
+		return func(row map[string]string) bool {
+			return re.MatchString(row[column]) == match
+		}, nil
+	case "==", "!=", ">", ">=", "<", "<=":
+		_, err := parseNumber(value)
+		numeric := err == nil
+		return func(row map[string]string) bool {
+			if _, err := parseNumber(row[column]); numeric && err != nil && op != "==" && op != "!=" {
+				// A value that isn't a number is neither greater nor less
+				// than a number
+				return false
+			}
+			c := compareValues(row[column], value)
+			switch op {
+			case "==":
+				return c == 0
+			case "!=":
+				return c != 0
+			case ">":
+				return c > 0
+			case ">=":
+				return c >= 0
+			case "<":
+				return c < 0
+			default:
+				return c <= 0
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+}
+
+// compareValues compares values numerically if both are numbers and as
+// strings otherwise
+func compareValues(a, b string) int {
+	_, errA := parseNumber(a)
+	_, errB := parseNumber(b)
+	if errA == nil && errB == nil {
+		return compareNumeric(a, b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,26 @@
+package query
+
+// Group is a group of rows that share the same value in a column
+type Group struct {
+	Value string
+	Rows  []map[string]string
+}
+
+// GroupBy groups the passed rows by their value in the passed column. Groups
+// are in the order their values first appear, and rows keep their order
+// within each group.
+func GroupBy(rows []map[string]string, column string) []*Group {
+	groups := []*Group{}
+	index := map[string]*Group{}
+	for _, row := range rows {
+		value := row[column]
+		group, ok := index[value]
+		if !ok {
+			group = &Group{Value: value}
+			index[value] = group
+			groups = append(groups, group)
+		}
+		group.Rows = append(group.Rows, row)
+	}
+	return groups
+}
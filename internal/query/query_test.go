@@ -0,0 +1,156 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QuerySuite struct {
+	suite.Suite
+	Rows []map[string]string
+}
+
+func (suite *QuerySuite) SetupTest() {
+	suite.Rows = []map[string]string{
+		{"name": "web10", "status": "running", "cpu": "5", "started": "2021-03-01"},
+		{"name": "web2", "status": "stopped", "cpu": "40", "started": "2021-01-15"},
+		{"name": "db", "status": "running", "cpu": "12.5", "started": "2020-12-31"},
+		{"name": "cache", "status": "running", "cpu": "n/a", "started": "unknown"},
+	}
+}
+
+func (suite *QuerySuite) names(rows []map[string]string) []string {
+	names := []string{}
+	for _, row := range rows {
+		names = append(names, row["name"])
+	}
+	return names
+}
+
+func (suite *QuerySuite) TestSortString() {
+	err := Sort(suite.Rows, []*SortKey{{Column: "name"}})
+	suite.NoError(err)
+	suite.Equal([]string{"cache", "db", "web10", "web2"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortNatural() {
+	err := Sort(suite.Rows, []*SortKey{{Column: "name", Comparator: NaturalCompare}})
+	suite.NoError(err)
+	suite.Equal([]string{"cache", "db", "web2", "web10"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortNumericDescending() {
+	err := Sort(suite.Rows, []*SortKey{{Column: "cpu", Comparator: NumericCompare, Descending: true}})
+	suite.NoError(err)
+	suite.Equal([]string{"web2", "db", "web10", "cache"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortDescendingPutsUnparsedValuesLast() {
+	rows := []map[string]string{{"name": "a", "cpu": "2"}, {"name": "b", "cpu": ""}, {"name": "c", "cpu": "10"}}
+	err := Sort(rows, []*SortKey{{Column: "cpu", Comparator: NumericCompare, Descending: true}})
+	suite.NoError(err)
+	suite.Equal([]string{"c", "a", "b"}, suite.names(rows))
+	err = Sort(suite.Rows, []*SortKey{{Column: "started", Comparator: DateCompare, Descending: true}})
+	suite.NoError(err)
+	suite.Equal([]string{"web10", "web2", "db", "cache"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortNumericPutsNonNumbersLast() {
+	err := Sort(suite.Rows, []*SortKey{{Column: "cpu", Comparator: NumericCompare}})
+	suite.NoError(err)
+	suite.Equal([]string{"web10", "db", "web2", "cache"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortDate() {
+	err := Sort(suite.Rows, []*SortKey{{Column: "started", Comparator: DateCompare}})
+	suite.NoError(err)
+	suite.Equal([]string{"db", "web2", "web10", "cache"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortMultipleKeys() {
+	err := Sort(suite.Rows, []*SortKey{
+		{Column: "status"},
+		{Column: "name", Descending: true},
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"web10", "db", "cache", "web2"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestSortUnknownComparator() {
+	err := Sort(suite.Rows, []*SortKey{{Column: "name", Comparator: "colour"}})
+	suite.EqualError(err, "Unknown comparator colour")
+}
+
+func (suite *QuerySuite) TestOrder() {
+	order, err := Order(suite.Rows, []*SortKey{{Column: "name"}})
+	suite.NoError(err)
+	suite.Equal([]int{3, 2, 0, 1}, order)
+	suite.Equal([]string{"web10", "web2", "db", "cache"}, suite.names(suite.Rows))
+}
+
+func (suite *QuerySuite) TestFilter() {
+	running := func(row map[string]string) bool { return row["status"] == "running" }
+	notCache := func(row map[string]string) bool { return row["name"] != "cache" }
+	actual := Filter(suite.Rows, running, notCache)
+	suite.Equal([]string{"web10", "db"}, suite.names(actual))
+	suite.Equal([]int{0, 2}, Select(suite.Rows, running, notCache))
+}
+
+func (suite *QuerySuite) TestParse() {
+	tests := []struct {
+		expression string
+		expected   []string
+	}{
+		{"status == running", []string{"web10", "db", "cache"}},
+		{"status != running", []string{"web2"}},
+		{"cpu > 10", []string{"web2", "db"}},
+		{"cpu <= 12.5", []string{"web10", "db"}},
+		{"name ~ ^web", []string{"web10", "web2"}},
+		{"name !~ '^web'", []string{"db", "cache"}},
+		{"status == running && cpu >= 5", []string{"web10", "db"}},
+		{"name == db || name == cache", []string{"db", "cache"}},
+		{"(name == db || name == web2) && status==running", []string{"db"}},
+		{`started == "2021-01-15"`, []string{"web2"}},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.expression, func() {
+			predicate, err := Parse(tt.expression)
+			suite.NoError(err)
+			suite.Equal(tt.expected, suite.names(Filter(suite.Rows, predicate)))
+		})
+	}
+}
+
+func (suite *QuerySuite) TestParseErrors() {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"status ==", `Unable to parse filter expression "status ==": unexpected end of expression`},
+		{"status = running", `Unable to parse filter expression "status = running": unexpected "="`},
+		{"name == 'web", `Unable to parse filter expression "name == 'web": unterminated quote`},
+		{"(name == db", `Unable to parse filter expression "(name == db": missing closing parenthesis`},
+		{"name == db db", `Unable to parse filter expression "name == db db": unexpected "db"`},
+		{"name ~ '('", `Unable to parse filter expression "name ~ '('": invalid regular expression "("`},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.expression, func() {
+			_, err := Parse(tt.expression)
+			suite.EqualError(err, tt.expected)
+		})
+	}
+}
+
+func (suite *QuerySuite) TestGroupBy() {
+	groups := GroupBy(suite.Rows, "status")
+	suite.Len(groups, 2)
+	suite.Equal("running", groups[0].Value)
+	suite.Equal([]string{"web10", "db", "cache"}, suite.names(groups[0].Rows))
+	suite.Equal("stopped", groups[1].Value)
+	suite.Equal([]string{"web2"}, suite.names(groups[1].Rows))
+}
+
+func TestQuerySuite(t *testing.T) {
+	suite.Run(t, new(QuerySuite))
+}
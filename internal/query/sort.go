@@ -0,0 +1,207 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tomguerney/printer/internal/dates"
+)
+
+// Comparators
+const (
+	StringCompare  = "string"
+	NumericCompare = "numeric"
+	NaturalCompare = "natural"
+	DateCompare    = "date"
+)
+
+// SortKey is a column to sort rows by. Comparator is one of StringCompare (the
+// default), NumericCompare, NaturalCompare or DateCompare. Values that the
+// numeric and date comparators can't parse sort last, even if Descending is
+// true.
+type SortKey struct {
+	Column     string
+	Descending bool
+	Comparator string
+}
+
+// Sort sorts the passed rows by the passed keys in order of precedence,
+// keeping rows that compare equal in their original order. It returns an error
+// if a key has an unknown comparator.
+func Sort(rows []map[string]string, keys []*SortKey) error {
+	order, err := Order(rows, keys)
+	if err != nil {
+		return err
+	}
+	sorted := make([]map[string]string, len(rows))
+	for i, j := range order {
+		sorted[i] = rows[j]
+	}
+	copy(rows, sorted)
+	return nil
+}
+
+// Order returns the indices of the passed rows in the order Sort sorts them,
+// without sorting the rows. It returns an error if a key has an unknown
+// comparator.
+func Order(rows []map[string]string, keys []*SortKey) ([]int, error) {
+	compares := make([]func(a, b string) int, len(keys))
+	parses := make([]func(string) bool, len(keys))
+	for i, key := range keys {
+		compare, parse, err := comparator(key.Comparator)
+		if err != nil {
+			return nil, err
+		}
+		compares[i], parses[i] = compare, parse
+	}
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := rows[order[i]], rows[order[j]]
+		for k, key := range keys {
+			if parses[k] != nil {
+				if okA, okB := parses[k](a[key.Column]), parses[k](b[key.Column]); okA != okB {
+					return okA
+				}
+			}
+			c := compares[k](a[key.Column], b[key.Column])
+			if c == 0 {
+				continue
+			}
+			if key.Descending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return order, nil
+}
+
+// comparator returns the function that compares values with the named
+// comparator and, if the comparator parses values, the function that reports
+// whether a value can be parsed
+func comparator(name string) (compare func(a, b string) int, parses func(string) bool, err error) {
+	switch name {
+	case "", StringCompare:
+		return strings.Compare, nil, nil
+	case NumericCompare:
+		return compareNumeric, isNumber, nil
+	case NaturalCompare:
+		return compareNatural, nil, nil
+	case DateCompare:
+		return compareDate, isDate, nil
+	default:
+		return nil, nil, fmt.Errorf("Unknown comparator %v", name)
+	}
+}
+
+// compareNumeric compares values as numbers. Values that aren't numbers sort
+// after those that are, and are compared as strings.
+func compareNumeric(a, b string) int {
+	x, errA := parseNumber(a)
+	y, errB := parseNumber(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return 1
+	case errB != nil:
+		return -1
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func parseNumber(s string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+}
+
+func isNumber(s string) bool {
+	_, err := parseNumber(s)
+	return err == nil
+}
+
+// compareNatural compares values so that runs of digits are compared by their
+// numeric value, e.g. "file2" sorts before "file10"
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		chunkA, restA := nextChunk(a)
+		chunkB, restB := nextChunk(b)
+		if isDigits(chunkA) && isDigits(chunkB) {
+			x := strings.TrimLeft(chunkA, "0")
+			y := strings.TrimLeft(chunkB, "0")
+			if len(x) != len(y) {
+				return compareInts(len(x), len(y))
+			}
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(chunkA, chunkB); c != 0 {
+			return c
+		}
+		a, b = restA, restB
+	}
+	return compareInts(len(a), len(b))
+}
+
+// nextChunk splits off the leading run of either digits or non-digits
+func nextChunk(s string) (chunk, rest string) {
+	digits := unicode.IsDigit(rune(s[0]))
+	for i, r := range s {
+		if unicode.IsDigit(r) != digits {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+func isDigits(s string) bool {
+	return s != "" && unicode.IsDigit(rune(s[0]))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareDate compares values as dates. Values that aren't dates sort after
+// those that are, and are compared as strings.
+func compareDate(a, b string) int {
+	x, okA := dates.Parse(a)
+	y, okB := dates.Parse(b)
+	switch {
+	case !okA && !okB:
+		return strings.Compare(a, b)
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	case x.Before(y):
+		return -1
+	case x.After(y):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isDate(s string) bool {
+	_, ok := dates.Parse(s)
+	return ok
+}
@@ -0,0 +1,200 @@
+package stenciller
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tomguerney/printer/internal/locale"
+)
+
+// Aggregates
+const (
+	Sum   = "sum"
+	Avg   = "avg"
+	Min   = "min"
+	Max   = "max"
+	Count = "count"
+)
+
+type unit struct {
+	scale  float64
+	family string
+}
+
+var units = map[string]unit{
+	"":    {1, ""},
+	"%":   {1, "%"},
+	"b":   {1, "bytes"},
+	"kb":  {1e3, "bytes"},
+	"mb":  {1e6, "bytes"},
+	"gb":  {1e9, "bytes"},
+	"tb":  {1e12, "bytes"},
+	"pb":  {1e15, "bytes"},
+	"kib": {1 << 10, "bytes"},
+	"mib": {1 << 20, "bytes"},
+	"gib": {1 << 30, "bytes"},
+	"tib": {1 << 40, "bytes"},
+	"pib": {1 << 50, "bytes"},
+}
+
+var quantityRegexp = regexp.MustCompile(`^([$€£¥]?)([-+]?(?:[0-9][0-9,]*(?:\.[0-9]*)?|\.[0-9]+))\s*([A-Za-z%]*)$`)
+
+// quantity is a number parsed from a string, with an optional currency symbol
+// prefix or unit suffix
+type quantity struct {
+	raw      string
+	value    float64
+	prefix   string
+	unit     string
+	scale    float64
+	family   string
+	decimals int
+}
+
+// parseQuantity parses strings such as "12", "1,024.5", "$3.50", "45%" and
+// "12MB" as numbers with an optional currency symbol or unit
+func parseQuantity(s string) (*quantity, error) {
+	matches := quantityRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return nil, fmt.Errorf("unable to parse %q as a number", s)
+	}
+	u, ok := units[strings.ToLower(matches[3])]
+	if !ok {
+		return nil, fmt.Errorf("unable to parse %q as a number: unknown unit %q", s, matches[3])
+	}
+	number := strings.ReplaceAll(matches[2], ",", "")
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %q as a number", s)
+	}
+	decimals := 0
+	if i := strings.IndexByte(number, '.'); i >= 0 {
+		decimals = len(number) - i - 1
+	}
+	family := u.family
+	if matches[1] != "" {
+		family = matches[1] + family
+	}
+	return &quantity{
+		raw:      strings.TrimSpace(s),
+		value:    value,
+		prefix:   matches[1],
+		unit:     matches[3],
+		scale:    u.scale,
+		family:   family,
+		decimals: decimals,
+	}, nil
+}
+
+// aggregate applies the named aggregate to the passed column values. Empty
+// values are ignored.
+func aggregate(name string, values []string) (string, error) {
+	nonEmpty := []string{}
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	if name == Count {
+		return strconv.Itoa(len(nonEmpty)), nil
+	}
+	if name != Sum && name != Avg && name != Min && name != Max {
+		return "", fmt.Errorf("unknown aggregate %v", name)
+	}
+	if len(nonEmpty) == 0 {
+		return "", nil
+	}
+	quantities := make([]*quantity, len(nonEmpty))
+	for i, value := range nonEmpty {
+		q, err := parseQuantity(value)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 && q.family != quantities[0].family {
+			return "", fmt.Errorf("unable to %v %q and %q as they have different units", name, quantities[0].raw, q.raw)
+		}
+		quantities[i] = q
+	}
+	switch name {
+	case Min, Max:
+		selected := quantities[0]
+		for _, q := range quantities[1:] {
+			if (name == Min) == (q.value*q.scale < selected.value*selected.scale) {
+				selected = q
+			}
+		}
+		return selected.raw, nil
+	default:
+		total := 0.0
+		largest := quantities[0]
+		decimals := 0
+		mixed := false
+		for _, q := range quantities {
+			total += q.value * q.scale
+			if q.scale > largest.scale {
+				largest = q
+			}
+			if q.decimals > decimals {
+				decimals = q.decimals
+			}
+			if q.unit != quantities[0].unit {
+				mixed = true
+			}
+		}
+		if name == Avg {
+			total /= float64(len(quantities))
+		}
+		result := total / largest.scale
+		if mixed || name == Avg {
+			decimals += 2
+		}
+		formatted := strconv.FormatFloat(result, 'f', decimals, 64)
+		if mixed || name == Avg {
+			formatted = trimZeros(formatted)
+		}
+		return largest.prefix + formatted + largest.unit, nil
+	}
+}
+
+func trimZeros(number string) string {
+	if !strings.Contains(number, ".") {
+		return number
+	}
+	return strings.TrimSuffix(strings.TrimRight(number, "0"), ".")
+}
+
+// createFooter applies the Table Stencil's footer aggregates to the passed row
+// maps to create its footer row, formatting aggregates with their column's value
+// format in the passed locale. It returns nil if the Stencil has no footer.
+func createFooter(stencil *TableStencil, dataMaps []map[string]string, l *locale.Locale) ([]string, error) {
+	if len(stencil.Footer) == 0 && len(stencil.FooterFuncs) == 0 {
+		return nil, nil
+	}
+	footer := make([]string, len(stencil.ColumnOrder))
+	for col, key := range stencil.ColumnOrder {
+		values := make([]string, len(dataMaps))
+		for i, m := range dataMaps {
+			values[i] = m[key]
+		}
+		var err error
+		if fn, ok := stencil.FooterFuncs[key]; ok {
+			footer[col], err = fn(values)
+		} else if name, ok := stencil.Footer[key]; ok {
+			footer[col], err = aggregate(name, values)
+			if format, ok := stencil.Formats[key]; ok && err == nil && name != Count {
+				if formatted, formatErr := format.ApplyLocale(footer[col], l); formatErr == nil {
+					footer[col] = formatted
+				}
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Unable to aggregate column %v of table stencil %v: %v", key, stencil.ID, err)
+		}
+	}
+	if stencil.FooterLabel != "" && len(footer) > 0 && footer[0] == "" {
+		footer[0] = stencil.FooterLabel
+	}
+	return footer, nil
+}
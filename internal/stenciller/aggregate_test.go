@@ -0,0 +1,121 @@
+package stenciller
+
+import "errors"
+
+func (suite *StencillerSuite) TestAggregate() {
+	tests := []struct {
+		name      string
+		aggregate string
+		values    []string
+		expected  string
+	}{
+		{"sum", Sum, []string{"1", "2", "3.5"}, "6.5"},
+		{"sum with separators", Sum, []string{"1,000", "250"}, "1250"},
+		{"sum with empty values", Sum, []string{"1", "", " "}, "1"},
+		{"sum of nothing", Sum, []string{"", ""}, ""},
+		{"sum of currency", Sum, []string{"$1.50", "$2.25"}, "$3.75"},
+		{"sum of bytes", Sum, []string{"12MB", "500KB"}, "12.5MB"},
+		{"sum of binary bytes", Sum, []string{"1GiB", "512MiB"}, "1.5GiB"},
+		{"sum of percentages", Sum, []string{"10%", "15%"}, "25%"},
+		{"avg", Avg, []string{"1", "2"}, "1.5"},
+		{"avg with decimals", Avg, []string{"1.0", "2.0", "2.0"}, "1.667"},
+		{"min", Min, []string{"3", "1", "2"}, "1"},
+		{"min with units", Min, []string{"2KB", "1MB", "500B"}, "500B"},
+		{"max", Max, []string{"3", "10", "2"}, "10"},
+		{"max with units", Max, []string{"2KB", "1MB", "500B"}, "1MB"},
+		{"count", Count, []string{"a", "", "b"}, "2"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			actual, err := aggregate(tt.aggregate, tt.values)
+			suite.NoError(err)
+			suite.Equal(tt.expected, actual)
+		})
+	}
+}
+
+func (suite *StencillerSuite) TestAggregateErrors() {
+	tests := []struct {
+		name      string
+		aggregate string
+		values    []string
+		expected  string
+	}{
+		{"unparseable", Sum, []string{"1", "lots"}, `unable to parse "lots" as a number`},
+		{"unknown unit", Sum, []string{"12 parsecs"}, `unable to parse "12 parsecs" as a number: unknown unit "parsecs"`},
+		{"mixed units", Sum, []string{"12MB", "5%"}, `unable to sum "12MB" and "5%" as they have different units`},
+		{"unknown aggregate", "median", []string{"1"}, "unknown aggregate median"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, err := aggregate(tt.aggregate, tt.values)
+			suite.EqualError(err, tt.expected)
+		})
+	}
+}
+
+func (suite *StencillerSuite) TestTableStencilWithFooter() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "cost", "size"},
+		Headers:     []string{"Name", "Cost", "Size"},
+		Footer:      map[string]string{"cost": Sum},
+		FooterFuncs: map[string]func([]string) (string, error){
+			"size": func(values []string) (string, error) { return "many", nil },
+		},
+		FooterLabel: "Total",
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	data := []map[string]string{
+		{"name": "web", "cost": "$10.50", "size": "1"},
+		{"name": "db", "cost": "$2000.25", "size": "2"},
+	}
+	expected := [][]string{
+		{"Name", "Cost", "Size"},
+		{"-----", "--------", "----"},
+		{"web", "$10.50", "1"},
+		{"db", "$2000.25", "2"},
+		{"-----", "--------", "----"},
+		{"Total", "$2010.75", "many"},
+	}
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+}
+
+func (suite *StencillerSuite) TestStencilTableCountsHeaderAndFooterRows() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "cost"},
+		Headers:     []string{"Name", "Cost"},
+		Footer:      map[string]string{"cost": Sum},
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	data := []map[string]string{{"name": "web", "cost": "1"}, {"name": "db", "cost": "2"}}
+	actual, err := suite.Stenciller.StencilTable(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(2, actual.HeaderRows)
+	suite.Equal(2, actual.FooterRows)
+	suite.Equal([]string{"web", "1"}, actual.Rows[2])
+	suite.Equal([]string{"", "3"}, actual.Rows[5])
+	stencil = &TableStencil{ID: "bare", ColumnOrder: []string{"name"}}
+	suite.Stenciller.AddTableStencil(stencil)
+	actual, err = suite.Stenciller.StencilTable(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(0, actual.HeaderRows)
+	suite.Equal(0, actual.FooterRows)
+	suite.Len(actual.Rows, 2)
+}
+
+func (suite *StencillerSuite) TestTableStencilWithFooterError() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "cost"},
+		FooterFuncs: map[string]func([]string) (string, error){
+			"name": func(values []string) (string, error) { return "", errors.New("failed") },
+		},
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	_, err := suite.Stenciller.UseTableStencil(stencil.ID, []map[string]string{{"name": "web"}})
+	suite.EqualError(err, "Unable to aggregate column name of table stencil test-id: failed")
+}
@@ -0,0 +1,233 @@
+package stenciller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomguerney/printer/internal/templater"
+)
+
+// AddPartial adds a partial template with the passed name, which the templates
+// of Template and List Stencils can include with {{ template "name" . }}. A
+// partial added to a child Stenciller overrides a partial of the same name
+// added to its parents. It returns a ValidationError if the template can't be
+// parsed.
+func (s *Stenciller) AddPartial(name, text string) error {
+	if name == "" {
+		return fmt.Errorf("Partial name may not be empty")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.partials[name]; ok {
+		return fmt.Errorf("Partial with name %v already exists", name)
+	}
+	v := &validation{kind: "partial", id: name}
+	t, err := templater.Parse(name, text, s.funcs())
+	if err != nil {
+		v.template(err)
+		return v.result(s.strict)
+	}
+	if s.partials == nil {
+		s.partials = map[string]*templater.Template{}
+	}
+	s.partials[name] = t
+	s.partialChanges++
+	return nil
+}
+
+// partialsVersion returns a number that changes whenever the partials of the
+// Stenciller or its parents change
+func (s *Stenciller) partialsVersion() int {
+	version := 0
+	if s.parent != nil {
+		version = s.parent.partialsVersion()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return version + s.partialChanges
+}
+
+// allPartials returns the partials of the Stenciller and its parents
+func (s *Stenciller) allPartials() map[string]*templater.Template {
+	partials := map[string]*templater.Template{}
+	if s.parent != nil {
+		partials = s.parent.allPartials()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for name, partial := range s.partials {
+		partials[name] = partial
+	}
+	return partials
+}
+
+// ResolveTableStencil returns a copy of the Table Stencil with the passed ID
+// with everything it inherits from the Stencils it extends. It returns an error
+// if it can't find the Stencil or a Stencil it extends, or if the Stencil
+// extends itself.
+func (s *Stenciller) ResolveTableStencil(id string) (*TableStencil, error) {
+	stencil, err := s.resolveTableStencil(id)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *stencil
+	return &resolved, nil
+}
+
+// resolveTableStencil finds the Table Stencil with the passed ID and resolves
+// its inheritance. Base Stencils are found from the Stenciller, so a child
+// Stenciller can override a base Stencil of its parent.
+func (s *Stenciller) resolveTableStencil(id string) (*TableStencil, error) {
+	stencil, err := s.findTableStencil(id)
+	if err != nil {
+		return nil, err
+	}
+	lineage, missing, cycle := s.lineage(stencil)
+	switch {
+	case missing != "":
+		return nil, fmt.Errorf("Unable to find base stencil %v of table stencil %v", missing, lineage[len(lineage)-1].ID)
+	case cycle:
+		return nil, fmt.Errorf("Table stencil %v has an inheritance cycle: %v", id, describeLineage(lineage))
+	}
+	resolved := lineage[len(lineage)-1]
+	for i := len(lineage) - 2; i >= 0; i-- {
+		resolved = extendTable(resolved, lineage[i])
+	}
+	return resolved, nil
+}
+
+// lineage returns a Table Stencil followed by each Stencil it extends in
+// turn. It also returns the ID of the first base Stencil that can't be found,
+// if any, and whether a Stencil extends one earlier in the lineage, in which
+// case that Stencil is the last of the lineage.
+func (s *Stenciller) lineage(stencil *TableStencil) (lineage []*TableStencil, missing string, cycle bool) {
+	lineage = []*TableStencil{stencil}
+	for stencil.Extends != "" {
+		for _, ancestor := range lineage {
+			if ancestor.ID == stencil.Extends {
+				return append(lineage, ancestor), "", true
+			}
+		}
+		base, err := s.findTableStencil(stencil.Extends)
+		if err != nil {
+			return lineage, stencil.Extends, false
+		}
+		lineage = append(lineage, base)
+		stencil = base
+	}
+	return lineage, "", false
+}
+
+// describeLineage describes a lineage, e.g. "a extends b extends a"
+func describeLineage(lineage []*TableStencil) string {
+	ids := make([]string, len(lineage))
+	for i, stencil := range lineage {
+		ids[i] = stencil.ID
+	}
+	return strings.Join(ids, " extends ")
+}
+
+// extendTable returns a Table Stencil that extends a resolved base Stencil.
+//
+// The column order is the Stencil's, if it has one, or otherwise the base's,
+// without the Stencil's removed columns. Headers, localized headers and widths
+// are the Stencil's, if it has them, or otherwise those of each column in the
+// base, with new columns headed by their key. Colors, formats, footers,
+// footer functions and defaults of the base are kept for the columns that are
+// kept, and are overridden by the Stencil's, where an empty color removes a
+// color. The footer label and data policies are the Stencil's, if it has them,
+// or otherwise the base's.
+func extendTable(base, stencil *TableStencil) *TableStencil {
+	columns := base.ColumnOrder
+	if len(stencil.ColumnOrder) > 0 {
+		columns = stencil.ColumnOrder
+	}
+	extended := &TableStencil{
+		ID:          stencil.ID,
+		FooterLabel: or(stencil.FooterLabel, base.FooterLabel),
+		UnknownKeys: or(stencil.UnknownKeys, base.UnknownKeys),
+		MissingKeys: or(stencil.MissingKeys, base.MissingKeys),
+	}
+	for _, column := range columns {
+		if !contains(stencil.RemoveColumns, column) {
+			extended.ColumnOrder = append(extended.ColumnOrder, column)
+		}
+	}
+	header := func(column string) string { return column }
+	extended.Headers = inherit(stencil.Headers, base.Headers, base.ColumnOrder, extended.ColumnOrder, header)
+	extended.Widths = inherit(stencil.Widths, base.Widths, base.ColumnOrder, extended.ColumnOrder, func(string) int { return 0 })
+	for tag, headers := range base.LocalizedHeaders {
+		if _, ok := stencil.LocalizedHeaders[tag]; !ok {
+			if extended.LocalizedHeaders == nil {
+				extended.LocalizedHeaders = map[string][]string{}
+			}
+			extended.LocalizedHeaders[tag] = inherit(nil, headers, base.ColumnOrder, extended.ColumnOrder, header)
+		}
+	}
+	for tag, headers := range stencil.LocalizedHeaders {
+		if extended.LocalizedHeaders == nil {
+			extended.LocalizedHeaders = map[string][]string{}
+		}
+		extended.LocalizedHeaders[tag] = headers
+	}
+	extended.Colors = merge(base.Colors, stencil.Colors, extended.ColumnOrder)
+	for column, color := range extended.Colors {
+		if color == "" {
+			delete(extended.Colors, column)
+		}
+	}
+	extended.Formats = merge(base.Formats, stencil.Formats, extended.ColumnOrder)
+	extended.Footer = merge(base.Footer, stencil.Footer, extended.ColumnOrder)
+	extended.FooterFuncs = merge(base.FooterFuncs, stencil.FooterFuncs, extended.ColumnOrder)
+	extended.Defaults = merge(base.Defaults, stencil.Defaults, extended.ColumnOrder)
+	return extended
+}
+
+// inherit returns the values of a Table Stencil for each of its columns, such
+// as its headers, which are its own values if it has any, or otherwise the
+// values of the base Stencil for the columns, with the fallback value for
+// columns the base doesn't have. It returns nil if neither Stencil has values.
+func inherit[V any](own, base []V, baseColumns, columns []string, fallback func(column string) V) []V {
+	if len(own) > 0 {
+		return own
+	}
+	if len(base) == 0 {
+		return nil
+	}
+	values := make([]V, len(columns))
+	for i, column := range columns {
+		values[i] = fallback(column)
+		for j, baseColumn := range baseColumns {
+			if baseColumn == column && j < len(base) {
+				values[i] = base[j]
+			}
+		}
+	}
+	return values
+}
+
+// merge returns the values of a base Stencil map for the passed columns,
+// overridden by every value of the Stencil's own map. It returns nil if the
+// result is empty.
+func merge[V any](base, own map[string]V, columns []string) map[string]V {
+	merged := map[string]V{}
+	for key, value := range base {
+		if contains(columns, key) {
+			merged[key] = value
+		}
+	}
+	for key, value := range own {
+		merged[key] = value
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+func or(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package stenciller
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *StencillerSuite) TestTemplateStencilWithPartials() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	suite.NoError(suite.Stenciller.AddPartial("header", "[{{ .name }}]"))
+	child := suite.Stenciller.Child()
+	suite.NoError(child.AddPartial("status", "{{ .status }}"))
+	suite.NoError(child.AddTemplateStencil(&TemplateStencil{
+		ID:       "test-id",
+		Template: `{{ template "header" . }} is {{ template "status" . }}`,
+	}))
+	actual, err := child.UseTemplateStencil("test-id", map[string]string{"name": "web", "status": "running"})
+	suite.NoError(err)
+	suite.Equal("[web] is running", actual)
+	suite.EqualError(suite.Stenciller.AddPartial("header", ""), "Partial with name header already exists")
+	suite.EqualError(suite.Stenciller.AddPartial("broken", "{{ .name }"), `Invalid partial stencil broken: template, line 1: unexpected "}" in operand`)
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithUnknownPartial() {
+	suite.Stenciller.SetStrict(true)
+	suite.NoError(suite.Stenciller.AddPartial("header", "[{{ .name }}]"))
+	err := suite.Stenciller.AddTemplateStencil(&TemplateStencil{
+		ID:       "test-id",
+		Template: `{{ template "header" . }} {{ template "footer" . }}`,
+		Colors:   map[string]string{"name": "red", "status": "green"},
+	})
+	suite.EqualError(err, "Invalid template stencil test-id: unknown partial footer; color for key status, which isn't used")
+}
+
+func (suite *StencillerSuite) TestResolveTableStencil() {
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:          "base",
+		ColumnOrder: []string{"name", "cpu", "mem"},
+		Headers:     []string{"Name", "CPU", "Memory"},
+		Colors:      map[string]string{"name": "bold", "cpu": "red", "mem": "blue"},
+		Footer:      map[string]string{"cpu": Sum, "mem": Sum},
+		FooterLabel: "Total",
+	}))
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:            "compact",
+		Extends:       "base",
+		RemoveColumns: []string{"cpu"},
+		Colors:        map[string]string{"name": "", "mem": "green"},
+	}))
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:          "zoned",
+		Extends:     "compact",
+		ColumnOrder: []string{"zone", "mem", "name"},
+		Defaults:    map[string]string{"zone": "-"},
+	}))
+	actual, err := suite.Stenciller.ResolveTableStencil("zoned")
+	suite.NoError(err)
+	suite.Equal(&TableStencil{
+		ID:          "zoned",
+		ColumnOrder: []string{"zone", "mem", "name"},
+		Headers:     []string{"zone", "Memory", "Name"},
+		Colors:      map[string]string{"mem": "green"},
+		Footer:      map[string]string{"mem": Sum},
+		FooterLabel: "Total",
+		Defaults:    map[string]string{"zone": "-"},
+	}, actual)
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	row, err := suite.Stenciller.UseTableStencilRow("zoned", map[string]string{"name": "web", "mem": "12"})
+	suite.NoError(err)
+	suite.Equal([]string{"-", "12", "web"}, row)
+}
+
+func (suite *StencillerSuite) TestAddTableStencilWithInheritanceProblems() {
+	suite.Stenciller.SetStrict(true)
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{ID: "base", ColumnOrder: []string{"name"}}))
+	err := suite.Stenciller.AddTableStencil(&TableStencil{ID: "test-id", Extends: "missing"})
+	suite.EqualError(err, "Invalid table stencil test-id: unknown base stencil missing")
+	err = suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:            "test-id",
+		Extends:       "base",
+		RemoveColumns: []string{"cpu"},
+		Colors:        map[string]string{"cpu": "red"},
+	})
+	suite.EqualError(err, "Invalid table stencil test-id: removed column cpu, which isn't inherited; color for column cpu, which isn't used")
+	err = suite.Stenciller.AddTableStencil(&TableStencil{ID: "test-id", ColumnOrder: []string{"name"}, RemoveColumns: []string{"name"}})
+	suite.EqualError(err, "Invalid table stencil test-id: removed columns without extending a stencil")
+}
+
+func (suite *StencillerSuite) TestResolveTableStencilWithCycle() {
+	err := suite.Stenciller.AddTableStencil(&TableStencil{ID: "a", Extends: "a"})
+	suite.EqualError(err, "Invalid table stencil a: inheritance cycle: a extends a")
+	child := suite.Stenciller.Child()
+	suite.NoError(child.AddTableStencil(&TableStencil{ID: "b", Extends: "c"}))
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{ID: "c", Extends: "b"}))
+	_, err = suite.Stenciller.ResolveTableStencil("c")
+	suite.EqualError(err, "Unable to find base stencil b of table stencil c")
+	_, err = child.ResolveTableStencil("c")
+	suite.EqualError(err, "Table stencil c has an inheritance cycle: c extends b extends c")
+	_, err = child.UseTableStencil("b", nil)
+	suite.EqualError(err, "Table stencil b has an inheritance cycle: b extends c extends b")
+	err = child.AddTableStencil(&TableStencil{ID: "d", Extends: "b"})
+	suite.EqualError(err, "Invalid table stencil d: inheritance cycle: d extends b extends c extends b")
+}
@@ -0,0 +1,99 @@
+package stenciller
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Data policies
+const (
+	PolicyIgnore = "ignore"
+	PolicyWarn   = "warn"
+	PolicyError  = "error"
+)
+
+// DataError is an error with a row of data applied to a Table Stencil. Row is
+// the index of the row, and Key is the key that isn't one of the Stencil's
+// columns, or the column missing from the row if Missing is true.
+type DataError struct {
+	Stencil string
+	Row     int
+	Key     string
+	Missing bool
+}
+
+func (e *DataError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("Row %v of table stencil %v is missing column %v", e.Row, e.Stencil, e.Key)
+	}
+	return fmt.Sprintf("Row %v of table stencil %v has unknown key %v", e.Row, e.Stencil, e.Key)
+}
+
+// applyDefaults returns the rows with the default value of each column of a
+// Table Stencil that is missing or empty in a row, and checks the keys of the
+// rows as per the Stencil's data policies. It returns a DataError for the first
+// problem with a policy of PolicyError, and logs problems with a policy of
+// PolicyWarn as warnings.
+func applyDefaults(stencil *TableStencil, rows []map[string]string) ([]map[string]string, error) {
+	if len(stencil.Defaults) == 0 && !checks(stencil.UnknownKeys) && !checks(stencil.MissingKeys) {
+		return rows, nil
+	}
+	applied := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		if checks(stencil.UnknownKeys) {
+			for _, key := range sortedKeys(row) {
+				if contains(stencil.ColumnOrder, key) {
+					continue
+				}
+				if err := check(stencil.UnknownKeys, &DataError{Stencil: stencil.ID, Row: i, Key: key}); err != nil {
+					return nil, err
+				}
+			}
+		}
+		applied[i] = row
+		copied := false
+		for _, column := range stencil.ColumnOrder {
+			if row[column] != "" {
+				continue
+			}
+			if value, ok := stencil.Defaults[column]; ok {
+				if !copied {
+					applied[i], copied = copyMap(row), true
+				}
+				applied[i][column] = value
+				continue
+			}
+			if _, ok := row[column]; ok || !checks(stencil.MissingKeys) {
+				continue
+			}
+			if err := check(stencil.MissingKeys, &DataError{Stencil: stencil.ID, Row: i, Key: column, Missing: true}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return applied, nil
+}
+
+// checks returns whether a data policy checks data
+func checks(policy string) bool {
+	return policy == PolicyWarn || policy == PolicyError
+}
+
+// check returns the passed error if the policy is PolicyError, and logs it as a
+// warning if the policy is PolicyWarn
+func check(policy string, err *DataError) error {
+	if policy == PolicyError {
+		return err
+	}
+	log.Warn().Msg(err.Error())
+	return nil
+}
+
+func copyMap(m map[string]string) map[string]string {
+	copied := make(map[string]string, len(m)+1)
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
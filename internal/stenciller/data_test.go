@@ -0,0 +1,66 @@
+package stenciller
+
+import (
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *StencillerSuite) TestTableStencilWithDefaults() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "zone", "cpu"},
+		Defaults:    map[string]string{"zone": "-", "cpu": "0"},
+		Footer:      map[string]string{"cpu": Sum},
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	rows := []map[string]string{{"name": "web", "zone": "eu", "cpu": "5"}, {"name": "db", "zone": ""}}
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, rows)
+	suite.NoError(err)
+	suite.Equal([][]string{
+		{"web", "eu", "5"},
+		{"db", "-", "0"},
+		{"---", "--", "-"},
+		{"", "", "5"},
+	}, actual)
+	suite.Equal(map[string]string{"name": "db", "zone": ""}, rows[1])
+	row, err := suite.Stenciller.UseTableStencilRow(stencil.ID, map[string]string{"name": "cache"})
+	suite.NoError(err)
+	suite.Equal([]string{"cache", "-", "0"}, row)
+}
+
+func (suite *StencillerSuite) TestTableStencilWithDataPolicies() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "zone", "cpu"},
+		Defaults:    map[string]string{"zone": "-"},
+		UnknownKeys: PolicyError,
+		MissingKeys: PolicyError,
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	rows := []map[string]string{{"name": "web", "cpu": "5"}, {"nmae": "db", "name": "db", "cpu": "1"}}
+	_, err := suite.Stenciller.UseTableStencil(stencil.ID, rows)
+	suite.EqualError(err, "Row 1 of table stencil test-id has unknown key nmae")
+	var dataErr *DataError
+	suite.True(errors.As(err, &dataErr))
+	suite.Equal(&DataError{Stencil: "test-id", Row: 1, Key: "nmae"}, dataErr)
+	_, err = suite.Stenciller.TableData(stencil.ID, []map[string]string{{"name": "web"}})
+	suite.EqualError(err, "Row 0 of table stencil test-id is missing column cpu")
+	_, err = suite.Stenciller.TableData(stencil.ID, []map[string]string{{"name": "web", "cpu": ""}})
+	suite.NoError(err)
+}
+
+func (suite *StencillerSuite) TestTableStencilWithWarnPolicy() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name"},
+		UnknownKeys: PolicyWarn,
+		MissingKeys: PolicyWarn,
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, []map[string]string{{"nmae": "web"}})
+	suite.NoError(err)
+	suite.Equal([][]string{{""}}, actual)
+}
@@ -0,0 +1,74 @@
+package stenciller
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/humanize"
+)
+
+func (suite *StencillerSuite) TestTableStencilWithFormats() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "size", "enabled"},
+		Headers:     []string{"Name", "Size", "Enabled"},
+		Footer:      map[string]string{"size": Sum, "name": Count},
+		Formats: map[string]*humanize.Format{
+			"name":    {Empty: "-"},
+			"size":    {Type: humanize.Bytes},
+			"enabled": {Type: humanize.Bool, True: "yes", False: "no"},
+		},
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	data := []map[string]string{
+		{"name": "web", "size": "1536", "enabled": "true"},
+		{"size": "1048576", "enabled": "false"},
+	}
+	expected := [][]string{
+		{"Name", "Size", "Enabled"},
+		{"----", "-------", "-------"},
+		{"web", "1.5 KiB", "yes"},
+		{"-", "1 MiB", "no"},
+		{"----", "-------", "-------"},
+		{"1", "1 MiB", ""},
+	}
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+	suite.Equal("1536", data[0]["size"])
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithFormats() {
+	stencil := &TemplateStencil{
+		ID:       "test-id",
+		Template: "{{.name}} is {{.size}}",
+		Colors:   map[string]string{"size": "red"},
+		Formats:  map[string]*humanize.Format{"size": {Type: humanize.Bytes, SI: true}},
+	}
+	suite.NoError(suite.Stenciller.AddTemplateStencil(stencil))
+	suite.Colorer.On("Color", "2 kB", "red").Return("red 2 kB", true)
+	actual, err := suite.Stenciller.UseTemplateStencil(stencil.ID, map[string]string{"name": "web", "size": "2000"})
+	suite.NoError(err)
+	suite.Equal("web is red 2 kB", actual)
+}
+
+func (suite *StencillerSuite) TestFormatsLeaveUnparseableValues() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"size"},
+		Formats:     map[string]*humanize.Format{"size": {Type: humanize.Bytes}},
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	actual, err := suite.Stenciller.UseTableStencilRow(stencil.ID, map[string]string{"size": "unknown"})
+	suite.NoError(err)
+	suite.Equal([]string{"unknown"}, actual)
+	suite.Colorer.AssertNotCalled(suite.T(), "Color", mock.Anything, mock.Anything)
+}
+
+func (suite *StencillerSuite) TestAddStencilWithInvalidFormat() {
+	suite.Stenciller.SetStrict(true)
+	err := suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"size"},
+		Formats:     map[string]*humanize.Format{"size": {Type: "sparkly"}},
+	})
+	suite.EqualError(err, "Invalid table stencil test-id: invalid format for column size: unknown value format sparkly")
+}
@@ -0,0 +1,301 @@
+package stenciller
+
+import (
+	"github.com/tomguerney/printer/internal/templater"
+)
+
+// Set is a set of Stencils and partials that are loaded together from a
+// source, such as a file
+type Set struct {
+	Partials  map[string]string
+	Templates []*TemplateStencil
+	Tables    []*TableStencil
+	Lists     []*ListStencil
+}
+
+// Load replaces the Stencils and partials loaded from the passed source with
+// those of the Set. The Set is validated as a whole against the other Stencils
+// of the Stenciller before any of it is swapped in, so if any of it can't be
+// added the error is returned and the Stencils last loaded from the source are
+// kept.
+// Table Stencils of the Set may extend each other in any order. Stencils loaded
+// from other sources that include a partial or extend a Table Stencil the Set
+// changes or removes are validated again, and the Set isn't loaded if one of
+// them can no longer find the partial or Stencil. Loading an empty Set removes
+// the Stencils of the source.
+func (s *Stenciller) Load(source string, set *Set) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before := s.copy()
+	scratch := s.copy()
+	scratch.remove(source)
+	for _, name := range sortedKeys(set.Partials) {
+		if err := scratch.AddPartial(name, set.Partials[name]); err != nil {
+			return err
+		}
+	}
+	for _, stencil := range set.Templates {
+		if err := scratch.AddTemplateStencil(stencil); err != nil {
+			return err
+		}
+	}
+	for _, stencil := range basesFirst(set.Tables) {
+		if err := scratch.AddTableStencil(stencil); err != nil {
+			return err
+		}
+	}
+	for _, stencil := range set.Lists {
+		if err := scratch.AddListStencil(stencil); err != nil {
+			return err
+		}
+	}
+	if err := revalidate(before, scratch, source, set); err != nil {
+		return err
+	}
+	s.remove(source)
+	s.partialChanges++
+	for _, name := range sortedKeys(set.Partials) {
+		if s.partials == nil {
+			s.partials = map[string]*templater.Template{}
+		}
+		if s.partialSources == nil {
+			s.partialSources = map[string]string{}
+		}
+		s.partials[name] = scratch.partials[name]
+		s.partialSources[name] = source
+	}
+	for _, stencil := range set.Templates {
+		stencil.source = source
+		s.templateStencils = append(s.templateStencils, stencil)
+	}
+	for _, stencil := range set.Tables {
+		stencil.source = source
+		s.tableStencils = append(s.tableStencils, stencil)
+	}
+	for _, stencil := range set.Lists {
+		stencil.source = source
+		s.listStencils = append(s.listStencils, stencil)
+	}
+	return nil
+}
+
+// copy returns a copy of the Stenciller with its own partials and Stencil
+// slices. The caller must hold the lock.
+func (s *Stenciller) copy() *Stenciller {
+	scratch := &Stenciller{
+		parent:           s.parent,
+		colorer:          s.colorer,
+		locale:           s.locale,
+		missingKey:       s.missingKey,
+		strict:           s.strict,
+		catalogs:         s.catalogs,
+		partials:         map[string]*templater.Template{},
+		partialSources:   map[string]string{},
+		templateStencils: append([]*TemplateStencil{}, s.templateStencils...),
+		tableStencils:    append([]*TableStencil{}, s.tableStencils...),
+		listStencils:     append([]*ListStencil{}, s.listStencils...),
+	}
+	for name, partial := range s.partials {
+		scratch.partials[name] = partial
+		scratch.partialSources[name] = s.partialSources[name]
+	}
+	return scratch
+}
+
+// revalidate validates the Stencils loaded from other sources that include a
+// partial or extend a Table Stencil loaded from the passed source, before or
+// after the Set is loaded. It returns a ValidationError if one of them includes
+// a partial or extends a Stencil that could be found before but can't be after,
+// whether or not the Stenciller is strict.
+func revalidate(before, after *Stenciller, source string, set *Set) error {
+	partials := map[string]bool{}
+	for name, partialSource := range before.partialSources {
+		partials[name] = partialSource == source
+	}
+	for name := range set.Partials {
+		partials[name] = true
+	}
+	tables := map[string]bool{}
+	for _, stencil := range before.tableStencils {
+		tables[stencil.ID] = stencil.source == source
+	}
+	for _, stencil := range set.Tables {
+		tables[stencil.ID] = true
+	}
+	beforePartials, afterPartials := before.allPartials(), after.allPartials()
+	brokenPartials := func(v *validation, templates map[string]*templater.Template) (bool, error) {
+		included, missingBefore := includes(templates, beforePartials)
+		if !anyOf(included, partials) {
+			return false, nil
+		}
+		_, missingAfter := includes(templates, afterPartials)
+		for _, name := range missingAfter {
+			if !contains(missingBefore, name) {
+				v.add("unknown partial %v", name)
+			}
+		}
+		v.fatal = true
+		return true, v.result(true)
+	}
+	for _, stencil := range before.templateStencils {
+		if stencil.source == source {
+			continue
+		}
+		dependent, err := brokenPartials(&validation{kind: "template", id: stencil.ID}, stencil.templates)
+		if err == nil && dependent {
+			_, err = after.validateTemplateStencil(stencil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, stencil := range before.listStencils {
+		if stencil.source == source {
+			continue
+		}
+		dependent, err := brokenPartials(&validation{kind: "list", id: stencil.ID}, stencil.templates)
+		if err == nil && dependent {
+			_, err = after.validateListStencil(stencil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, stencil := range before.tableStencils {
+		if stencil.source == source || stencil.Extends == "" {
+			continue
+		}
+		beforeLineage, missingBefore, cycleBefore := before.lineage(stencil)
+		afterLineage, missingAfter, cycleAfter := after.lineage(stencil)
+		bases := append(tableIDs(beforeLineage[1:]), tableIDs(afterLineage[1:])...)
+		if !anyOf(append(bases, missingBefore, missingAfter), tables) {
+			continue
+		}
+		v := &validation{kind: "table", id: stencil.ID, fatal: true}
+		switch {
+		case missingAfter != "" && missingBefore == "" && !cycleBefore:
+			v.add("unknown base stencil %v", missingAfter)
+		case cycleAfter && !cycleBefore:
+			v.add("inheritance cycle: %v", describeLineage(afterLineage))
+		}
+		if err := v.result(true); err != nil {
+			return err
+		}
+		if err := after.validateTableStencil(stencil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// includes returns the names of the partials the passed templates include,
+// directly or through other partials, and of those that aren't in the passed
+// partials
+func includes(templates map[string]*templater.Template, partials map[string]*templater.Template) (included, missing []string) {
+	seen := map[string]bool{}
+	var include func(t *templater.Template)
+	include = func(t *templater.Template) {
+		for _, name := range t.Partials() {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			included = append(included, name)
+			partial, ok := partials[name]
+			if !ok {
+				missing = append(missing, name)
+				continue
+			}
+			include(partial)
+		}
+	}
+	for _, text := range sortedKeys(templates) {
+		include(templates[text])
+	}
+	return included, missing
+}
+
+// anyOf returns whether any of the passed names is true in the passed map
+func anyOf(names []string, set map[string]bool) bool {
+	for _, name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// tableIDs returns the IDs of the passed Table Stencils
+func tableIDs(stencils []*TableStencil) []string {
+	ids := make([]string, len(stencils))
+	for i, stencil := range stencils {
+		ids[i] = stencil.ID
+	}
+	return ids
+}
+
+// remove removes the Stencils and partials loaded from the passed source. The
+// caller must hold the write lock.
+func (s *Stenciller) remove(source string) {
+	for name, partialSource := range s.partialSources {
+		if partialSource == source {
+			delete(s.partials, name)
+			delete(s.partialSources, name)
+		}
+	}
+	templates := s.templateStencils[:0:0]
+	for _, stencil := range s.templateStencils {
+		if stencil.source != source {
+			templates = append(templates, stencil)
+		}
+	}
+	s.templateStencils = templates
+	tables := s.tableStencils[:0:0]
+	for _, stencil := range s.tableStencils {
+		if stencil.source != source {
+			tables = append(tables, stencil)
+		}
+	}
+	s.tableStencils = tables
+	lists := s.listStencils[:0:0]
+	for _, stencil := range s.listStencils {
+		if stencil.source != source {
+			lists = append(lists, stencil)
+		}
+	}
+	s.listStencils = lists
+}
+
+// basesFirst orders Table Stencils so each comes after the Stencil it extends,
+// if it's one of them. Stencils whose bases can't be found are last.
+func basesFirst(stencils []*TableStencil) []*TableStencil {
+	ordered := make([]*TableStencil, 0, len(stencils))
+	added := map[string]bool{}
+	pending := stencils
+	for len(pending) > 0 {
+		remaining := pending[:0:0]
+		for _, stencil := range pending {
+			if stencil.Extends == "" || added[stencil.Extends] || !extendsAny(stencil, pending) {
+				ordered = append(ordered, stencil)
+				added[stencil.ID] = true
+			} else {
+				remaining = append(remaining, stencil)
+			}
+		}
+		if len(remaining) == len(pending) {
+			return append(ordered, remaining...)
+		}
+		pending = remaining
+	}
+	return ordered
+}
+
+// extendsAny returns whether a Table Stencil extends one of the passed Stencils
+func extendsAny(stencil *TableStencil, stencils []*TableStencil) bool {
+	for _, other := range stencils {
+		if other != stencil && other.ID == stencil.Extends {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package stenciller
+
+import (
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *StencillerSuite) TestLoad() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{ID: "added", ColumnOrder: []string{"name"}}))
+	suite.NoError(suite.Stenciller.Load("stencils.json", &Set{
+		Partials:  map[string]string{"header": "[{{ .name }}]"},
+		Templates: []*TemplateStencil{{ID: "status", Template: `{{ template "header" . }} is {{ .status }}`}},
+		Tables: []*TableStencil{
+			{ID: "compact", Extends: "base", RemoveColumns: []string{"cpu"}},
+			{ID: "base", ColumnOrder: []string{"name", "cpu"}},
+		},
+	}))
+	actual, err := suite.Stenciller.UseTemplateStencil("status", map[string]string{"name": "web", "status": "running"})
+	suite.NoError(err)
+	suite.Equal("[web] is running", actual)
+	resolved, err := suite.Stenciller.ResolveTableStencil("compact")
+	suite.NoError(err)
+	suite.Equal([]string{"name"}, resolved.ColumnOrder)
+	suite.NoError(suite.Stenciller.Load("stencils.json", &Set{
+		Templates: []*TemplateStencil{{ID: "status", Template: "{{ .name }}: {{ .status }}"}},
+	}))
+	actual, err = suite.Stenciller.UseTemplateStencil("status", map[string]string{"name": "web", "status": "running"})
+	suite.NoError(err)
+	suite.Equal("web: running", actual)
+	_, err = suite.Stenciller.findTableStencil("base")
+	suite.Error(err)
+	suite.Empty(suite.Stenciller.allPartials())
+	_, err = suite.Stenciller.findTableStencil("added")
+	suite.NoError(err)
+}
+
+func (suite *StencillerSuite) TestLoadKeepsLastGoodSet() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	suite.NoError(suite.Stenciller.Load("stencils.json", &Set{
+		Templates: []*TemplateStencil{{ID: "status", Template: "{{ .status }}"}},
+		Lists:     []*ListStencil{{ID: "items", Template: "- {{ .name }}"}},
+	}))
+	err := suite.Stenciller.Load("stencils.json", &Set{
+		Templates: []*TemplateStencil{{ID: "status", Template: "{{ .status }!"}},
+		Lists:     []*ListStencil{{ID: "items", Template: "* {{ .name }}"}},
+	})
+	var validationErr *ValidationError
+	suite.True(errors.As(err, &validationErr))
+	suite.Equal("status", validationErr.ID)
+	actual, err := suite.Stenciller.UseTemplateStencil("status", map[string]string{"status": "running"})
+	suite.NoError(err)
+	suite.Equal("running", actual)
+	items, err := suite.Stenciller.UseListStencil("items", []map[string]string{{"name": "web"}})
+	suite.NoError(err)
+	suite.Equal([]string{"- web"}, items)
+	err = suite.Stenciller.Load("other.json", &Set{Templates: []*TemplateStencil{{ID: "status"}}})
+	suite.EqualError(err, "Template Stencil with ID status already exists")
+	suite.NoError(suite.Stenciller.Load("stencils.json", &Set{}))
+	_, err = suite.Stenciller.findTemplateStencil("status")
+	suite.Error(err)
+}
+
+func (suite *StencillerSuite) TestLoadRevalidatesDependents() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	suite.NoError(suite.Stenciller.Load("base.json", &Set{
+		Partials: map[string]string{"header": "[{{ .name }}]"},
+		Tables:   []*TableStencil{{ID: "base", ColumnOrder: []string{"name", "cpu"}}},
+	}))
+	suite.NoError(suite.Stenciller.Load("stencils.json", &Set{
+		Templates: []*TemplateStencil{{ID: "status", Template: `{{ template "header" . }} is {{ .status }}`}},
+		Tables:    []*TableStencil{{ID: "compact", Extends: "base", RemoveColumns: []string{"cpu"}}},
+	}))
+	err := suite.Stenciller.Load("base.json", &Set{
+		Tables: []*TableStencil{{ID: "base", ColumnOrder: []string{"name", "cpu"}}},
+	})
+	suite.EqualError(err, "Invalid template stencil status: unknown partial header")
+	err = suite.Stenciller.Load("base.json", &Set{
+		Partials: map[string]string{"header": `{{ template "title" . }}`},
+		Tables:   []*TableStencil{{ID: "base", ColumnOrder: []string{"name", "cpu"}}},
+	})
+	suite.EqualError(err, "Invalid template stencil status: unknown partial title")
+	err = suite.Stenciller.Load("base.json", &Set{
+		Partials: map[string]string{"header": "[{{ .name }}]"},
+	})
+	suite.EqualError(err, "Invalid table stencil compact: unknown base stencil base")
+	err = suite.Stenciller.Load("base.json", &Set{})
+	suite.Error(err)
+	actual, err := suite.Stenciller.UseTemplateStencil("status", map[string]string{"name": "web", "status": "running"})
+	suite.NoError(err)
+	suite.Equal("[web] is running", actual)
+	_, err = suite.Stenciller.ResolveTableStencil("compact")
+	suite.NoError(err)
+	suite.NoError(suite.Stenciller.Load("base.json", &Set{
+		Partials: map[string]string{"header": "<{{ .name }}>"},
+		Tables:   []*TableStencil{{ID: "base", ColumnOrder: []string{"name", "cpu", "mem"}}},
+	}))
+	actual, err = suite.Stenciller.UseTemplateStencil("status", map[string]string{"name": "web", "status": "running"})
+	suite.NoError(err)
+	suite.Equal("<web> is running", actual)
+}
+
+func (suite *StencillerSuite) TestLoadAndAddWithSameID() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	errs := make(chan error, 2)
+	go func() {
+		errs <- suite.Stenciller.Load("stencils.json", &Set{Templates: []*TemplateStencil{{ID: "status", Template: "{{ .status }}"}}})
+	}()
+	go func() {
+		errs <- suite.Stenciller.AddTemplateStencil(&TemplateStencil{ID: "status", Template: "{{ .status }}"})
+	}()
+	first, second := <-errs, <-errs
+	suite.True((first == nil) != (second == nil))
+	suite.Len(suite.Stenciller.templateStencils, 1)
+}
@@ -0,0 +1,99 @@
+package stenciller
+
+import (
+	"github.com/tomguerney/printer/internal/humanize"
+	"github.com/tomguerney/printer/internal/locale"
+)
+
+func (suite *StencillerSuite) setLocale(tag string) {
+	l, err := locale.Lookup(tag)
+	suite.NoError(err)
+	suite.Stenciller.SetLocale(l)
+}
+
+func (suite *StencillerSuite) TestTableStencilWithCatalog() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name", "size"},
+		Headers:     []string{"Name", "Size"},
+		Formats:     map[string]*humanize.Format{"size": {Type: humanize.Thousands}},
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	suite.Stenciller.AddCatalog("de", map[string]string{"Size": "Dateigröße"})
+	suite.setLocale("de-AT")
+	expected := [][]string{
+		{"Name", "Dateigröße"},
+		{"----", "----------"},
+		{"web", "1.234.567"},
+	}
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, []map[string]string{{"name": "web", "size": "1234567"}})
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+	suite.Equal([]string{"Name", "Size"}, stencil.Headers)
+}
+
+func (suite *StencillerSuite) TestTableStencilWithLocalizedHeaders() {
+	stencil := &TableStencil{
+		ID:               "test-id",
+		ColumnOrder:      []string{"name"},
+		Headers:          []string{"Name"},
+		LocalizedHeaders: map[string][]string{"fr": {"Nom"}},
+	}
+	suite.NoError(suite.Stenciller.AddTableStencil(stencil))
+	suite.setLocale("fr_FR.UTF-8")
+	table, err := suite.Stenciller.TableData(stencil.ID, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"Nom"}, table.Headers)
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithLocalizedVariant() {
+	stencil := &TemplateStencil{
+		ID:        "test-id",
+		Template:  "{{.name}} is ready",
+		Localized: map[string]string{"pt-BR": "{{.name}} está pronto"},
+	}
+	suite.NoError(suite.Stenciller.AddTemplateStencil(stencil))
+	data := map[string]string{"name": "web"}
+	actual, err := suite.Stenciller.UseTemplateStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal("web is ready", actual)
+	suite.setLocale("pt-BR")
+	actual, err = suite.Stenciller.UseTemplateStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal("web está pronto", actual)
+	suite.setLocale("pt")
+	actual, err = suite.Stenciller.UseTemplateStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal("web is ready", actual)
+}
+
+func (suite *StencillerSuite) TestChildUsesParentCatalogs() {
+	suite.Stenciller.AddCatalog("de", map[string]string{"Name": "Bezeichnung"})
+	suite.setLocale("de")
+	child := suite.Stenciller.Child()
+	child.AddCatalog("de", map[string]string{"Status": "Zustand"})
+	suite.Equal("Bezeichnung", child.Message("Name"))
+	suite.Equal("Zustand", child.Message("Status"))
+	suite.Equal("Status", suite.Stenciller.Message("Status"))
+}
+
+func (suite *StencillerSuite) TestSetLocaleWhileStencilling() {
+	suite.NoError(suite.Stenciller.AddTemplateStencil(&TemplateStencil{ID: "files", Template: `{{ plural .n "# file" "# files" }}`}))
+	l, err := locale.Lookup("de")
+	suite.NoError(err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			suite.Stenciller.SetLocale(l)
+			suite.Stenciller.AddCatalog("de", map[string]string{"file": "Datei"})
+			suite.Stenciller.SetMissingKey("")
+			suite.Stenciller.SetStrict(false)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, err := suite.Stenciller.UseTemplateStencil("files", map[string]string{"n": "1000"})
+		suite.NoError(err)
+	}
+	<-done
+}
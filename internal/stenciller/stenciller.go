@@ -3,11 +3,15 @@ package stenciller
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/rs/zerolog/log"
 
-	"github.com/tomguerney/interpolator"
 	c "github.com/tomguerney/printer/internal/colorer"
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/humanize"
+	"github.com/tomguerney/printer/internal/locale"
+	"github.com/tomguerney/printer/internal/templater"
 )
 
 // Stenciller formats "data" maps of string key/value pairs according to
@@ -30,25 +34,116 @@ import (
 // matches a key in the Stencil's color map and transforms the data value string
 // to the color of the color value. It returns the rows and columns as a 2D
 // string slice with a prefixed header row.
+//
+// A List Stencil is comprised of an ID, a "color" map of string key/value pairs,
+// and a template string. When a List Stencil is applied to a slice of "item"
+// maps of string key/value pairs, each item map is colored and applied to the
+// template in the same way as a Template Stencil, producing one string per
+// item.
+//
+// A child Stenciller can be created from a Stenciller. A child finds any
+// Stencil its parent can, but Stencils added to the child aren't added to the
+// parent.
+//
+// Every kind of Stencil can also have a "formats" map of keys to value formats,
+// which format the data value of each matching key for humans, such as
+// formatting a number of bytes as "1.5 KiB", before it is colored.
+//
+// If a locale is set, values are formatted for the locale, and Template
+// Stencils, List Stencils and the headers of Table Stencils use their variant
+// for the locale if they have one. Otherwise their templates, headers and
+// footer labels are translated by the message catalog of the locale, if one
+// has been added with a message for them.
+//
+// Templates are parsed when their Stencil is added, and keys of a template that
+// are missing from a data map are handled as per the missing key policy.
+// Templates can include partials added to the Stenciller or its parents by
+// name, and a Table Stencil can extend another, inheriting its columns.
 type Stenciller struct {
+	mu               sync.RWMutex
+	parent           *Stenciller
 	colorer          colorer
+	locale           *locale.Locale
+	missingKey       string
+	strict           bool
+	catalogs         map[string]map[string]string
+	partials         map[string]*templater.Template
+	partialSources   map[string]string
+	partialChanges   int
 	templateStencils []*TemplateStencil
 	tableStencils    []*TableStencil
+	listStencils     []*ListStencil
+	cacheMu          sync.Mutex
+	cache            map[templateKey]*templater.Template
+	cacheVersion     int
+}
+
+// templateKey is the key of a template bound to the partials in the cache of a
+// Stenciller
+type templateKey struct {
+	id, text string
 }
 
 // TemplateStencil is a template stencil
 type TemplateStencil struct {
-	ID       string
-	Template string
-	Colors   map[string]string
+	ID        string
+	Template  string
+	Colors    map[string]string
+	Formats   map[string]*humanize.Format
+	Localized map[string]string
+	templates map[string]*templater.Template
+	source    string
 }
 
 // TableStencil table stencils
 type TableStencil struct {
-	ID          string
-	Colors      map[string]string
-	ColumnOrder []string
-	Headers     []string
+	ID               string
+	Colors           map[string]string
+	ColumnOrder      []string
+	Headers          []string
+	Footer           map[string]string
+	FooterFuncs      map[string]func(values []string) (string, error)
+	FooterLabel      string
+	Widths           []int
+	Formats          map[string]*humanize.Format
+	LocalizedHeaders map[string][]string
+	Defaults         map[string]string
+	UnknownKeys      string
+	MissingKeys      string
+	Extends          string
+	RemoveColumns    []string
+	source           string
+}
+
+// Table is the uncolored result of applying a Table Stencil to a slice of row
+// maps. Rows are in column order, and Colors holds the color of each column,
+// or an empty string if the column isn't colored.
+type Table struct {
+	Headers []string
+	Columns []string
+	Rows    [][]string
+	Colors  []string
+	Widths  []int
+}
+
+// StencilledTable is the colored result of applying a Table Stencil to a slice
+// of row maps. Rows holds HeaderRows rows of headers, then a row for each row
+// map, then FooterRows rows of footer.
+type StencilledTable struct {
+	Rows       [][]string
+	HeaderRows int
+	FooterRows int
+}
+
+// ListStencil is a list stencil
+type ListStencil struct {
+	ID        string
+	Template  string
+	Colors    map[string]string
+	Formats   map[string]*humanize.Format
+	Localized map[string]string
+	templates map[string]*templater.Template
+	source    string
 }
 
 type colorer interface {
@@ -60,39 +155,141 @@ func New() *Stenciller {
 	return &Stenciller{colorer: c.New()}
 }
 
+// Child returns a new child Stenciller of the Stenciller
+func (s *Stenciller) Child() *Stenciller {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &Stenciller{parent: s, colorer: s.colorer, locale: s.locale, missingKey: s.missingKey, strict: s.strict}
+}
+
+// SetLocale sets the locale values are formatted for and Stencils are
+// localized for. A nil locale formats values in the default locale and doesn't
+// localize Stencils.
+func (s *Stenciller) SetLocale(l *locale.Locale) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locale = l
+}
+
+// currentLocale returns the locale set with SetLocale
+func (s *Stenciller) currentLocale() *locale.Locale {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.locale
+}
+
+// AddCatalog adds a message catalog for the locale with the passed tag. The
+// catalog maps the text of templates, headers and footer labels to their
+// translation. Messages are added to any existing catalog for the locale.
+func (s *Stenciller) AddCatalog(tag string, messages map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.catalogs == nil {
+		s.catalogs = map[string]map[string]string{}
+	}
+	tag = locale.Normalize(tag)
+	if s.catalogs[tag] == nil {
+		s.catalogs[tag] = map[string]string{}
+	}
+	for text, message := range messages {
+		s.catalogs[tag][text] = message
+	}
+}
+
+// Message returns the translation of the passed text in the message catalog of
+// the current locale, or the text itself if there's no translation
+func (s *Stenciller) Message(text string) string {
+	l := s.currentLocale()
+	if l == nil || text == "" {
+		return text
+	}
+	for _, tag := range l.Candidates() {
+		for st := s; st != nil; st = st.parent {
+			if message, ok := st.catalogMessage(tag, text); ok {
+				return message
+			}
+		}
+	}
+	return text
+}
+
+// catalogMessage returns the translation of the passed text in the message
+// catalog for the locale with the passed tag, if it has one
+func (s *Stenciller) catalogMessage(tag, text string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	message, ok := s.catalogs[tag][text]
+	return message, ok
+}
+
 // Color does a color
 func (s *Stenciller) Color(text, color string) (string, bool) {
 	return s.colorer.Color(text, color)
 }
 
-// AddTemplateStencil adds a new Template Stencil
+// AddTemplateStencil adds a new Template Stencil. It returns a ValidationError
+// if its template or a localized variant of it can't be parsed, or, if the
+// Stenciller is strict, if it has an unknown color, an invalid format, or a
+// color or format for a key its template doesn't use.
 func (s *Stenciller) AddTemplateStencil(stencil *TemplateStencil) error {
 	if stencil.ID == "" {
 		return fmt.Errorf("Stencil ID may not be empty")
 	}
-	for _, existing := range s.templateStencils {
-		if stencil.ID == existing.ID {
-			return fmt.Errorf("Template Stencil with ID %v already exists", stencil.ID)
-		}
+	templates, err := s.validateTemplateStencil(stencil)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hasTemplateStencil(stencil.ID) {
+		return fmt.Errorf("Template Stencil with ID %v already exists", stencil.ID)
 	}
+	stencil.templates = templates
 	s.templateStencils = append(s.templateStencils, stencil)
 	return nil
 }
 
-// AddTableStencil adds a new Table Stencil
+// AddTableStencil adds a new Table Stencil. If the Stenciller is strict, it
+// returns a ValidationError if the Stencil has duplicate columns, a different
+// number of headers, localized headers or widths than columns, an unknown color
+// or aggregate, an invalid format, or a color, format or footer for a column
+// that isn't in its column order.
 func (s *Stenciller) AddTableStencil(stencil *TableStencil) error {
 	if stencil.ID == "" {
 		return fmt.Errorf("Stencil ID may not be empty")
 	}
-	for _, existing := range s.tableStencils {
-		if stencil.ID == existing.ID {
-			return fmt.Errorf("Table Stencil with ID %v already exists", stencil.ID)
-		}
+	if err := s.validateTableStencil(stencil); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hasTableStencil(stencil.ID) {
+		return fmt.Errorf("Table Stencil with ID %v already exists", stencil.ID)
 	}
 	s.tableStencils = append(s.tableStencils, stencil)
 	return nil
 }
 
+// AddListStencil adds a new List Stencil. It returns a ValidationError as per
+// AddTemplateStencil.
+func (s *Stenciller) AddListStencil(stencil *ListStencil) error {
+	if stencil.ID == "" {
+		return fmt.Errorf("Stencil ID may not be empty")
+	}
+	templates, err := s.validateListStencil(stencil)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hasListStencil(stencil.ID) {
+		return fmt.Errorf("List Stencil with ID %v already exists", stencil.ID)
+	}
+	stencil.templates = templates
+	s.listStencils = append(s.listStencils, stencil)
+	return nil
+}
+
 // UseTemplateStencil takes the ID of a Template Stencil and a "data" map with string
 // key/value pairs. It returns an error if it can't find a Stencil with the
 // passed ID or template interpolation fails. It applies the Template Stencil to
@@ -102,12 +299,9 @@ func (s *Stenciller) UseTemplateStencil(id string, data map[string]string) (stri
 	if err != nil {
 		return "", err
 	}
-	coloredData := s.colorMap(stencil.Colors, data)
-	interpolated, err := interpolator.Interpolate(stencil.Template, coloredData)
-	if err != nil {
-		return "", err
-	}
-	return interpolated, nil
+	coloredData := s.colorMap(stencil.Colors, s.formatMap(stencil.Formats, data))
+	template := s.localizeTemplate(stencil.Template, stencil.Localized)
+	return s.interpolate(stencil.ID, stencil.templates, template, coloredData)
 }
 
 // UseTableStencil takes the ID of a Table Stencil and a slice of "row" maps with
@@ -115,42 +309,213 @@ func (s *Stenciller) UseTemplateStencil(id string, data map[string]string) (stri
 // passed ID. It applies the Table Stencil to the row map slice to create a 2D
 // slice. If the Headers fields of the Stencil isn't empty, it will will prepend
 // the headers to the 2D slice with a dynamically-sized divider row before
-// returning the result.
-func (s *Stenciller) UseTableStencil(id string, data []map[string]string) (coloredSlices [][]string, err error) {
-	stencil, err := s.findTableStencil(id)
+// returning the result. If the Stencil has a footer, it will append a second
+// divider row and the footer row, and will return an error if a column can't
+// be aggregated.
+//
+// Columns missing or empty in a row are given the Stencil's default value for
+// the column, if it has one. Keys of a row that aren't in the column order are
+// ignored and other missing columns are left empty, unless the Stencil's
+// UnknownKeys or MissingKeys policy is PolicyWarn, which logs a warning, or
+// PolicyError, which returns a DataError.
+func (s *Stenciller) UseTableStencil(id string, data []map[string]string) ([][]string, error) {
+	table, err := s.StencilTable(id, data)
+	if err != nil {
+		return nil, err
+	}
+	return table.Rows, nil
+}
+
+// StencilTable applies a Table Stencil to a slice of "row" maps as per
+// UseTableStencil, and returns the result along with the number of header and
+// footer rows it has.
+func (s *Stenciller) StencilTable(id string, data []map[string]string) (*StencilledTable, error) {
+	stencil, err := s.resolveTableStencil(id)
 	if err != nil {
 		return nil, err
 	}
+	stencil = s.localizeTable(stencil)
+	if data, err = applyDefaults(stencil, data); err != nil {
+		return nil, err
+	}
+	footer, err := createFooter(stencil, data, s.currentLocale())
+	if err != nil {
+		return nil, err
+	}
+	data = s.formatMaps(stencil.Formats, data)
+	table := &StencilledTable{}
 	for _, d := range data {
 		coloredData := s.colorMap(stencil.Colors, d)
 		coloredSlice := mapToSliceInColumnOrder(coloredData, stencil.ColumnOrder)
-		coloredSlices = append(coloredSlices, coloredSlice)
+		table.Rows = append(table.Rows, coloredSlice)
+	}
+	if headerSlices, ok := createHeaderSlices(stencil, data, footer); ok {
+		table.Rows = append(headerSlices, table.Rows...)
+		table.HeaderRows = len(headerSlices)
+	}
+	if footer != nil {
+		table.Rows = append(table.Rows, createDivRowFor(stencil, data, footer), footer)
+		table.FooterRows = 2
+	}
+	return table, nil
+}
+
+// UseTableStencilRow takes the ID of a Table Stencil and a single "row" map with
+// string key/values. It returns an error if it can't find a Stencil with the
+// passed ID. It applies the Table Stencil to the row map and returns the
+// colored row in column order, without headers or a footer.
+func (s *Stenciller) UseTableStencilRow(id string, data map[string]string) ([]string, error) {
+	stencil, err := s.resolveTableStencil(id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := applyDefaults(stencil, []map[string]string{data})
+	if err != nil {
+		return nil, err
+	}
+	coloredData := s.colorMap(stencil.Colors, s.formatMap(stencil.Formats, rows[0]))
+	return mapToSliceInColumnOrder(coloredData, stencil.ColumnOrder), nil
+}
+
+// TableData takes the ID of a Table Stencil and a slice of "row" maps with
+// string key/values. It returns an error if it can't find a Stencil with the
+// passed ID. It applies the Table Stencil's column order to the row maps
+// without coloring them, and returns the result along with the Stencil's
+// headers and the color of each column.
+func (s *Stenciller) TableData(id string, data []map[string]string) (*Table, error) {
+	stencil, err := s.resolveTableStencil(id)
+	if err != nil {
+		return nil, err
+	}
+	stencil = s.localizeTable(stencil)
+	if data, err = applyDefaults(stencil, data); err != nil {
+		return nil, err
+	}
+	table := &Table{
+		Headers: stencil.Headers,
+		Columns: stencil.ColumnOrder,
+		Rows:    make([][]string, len(data)),
+		Colors:  make([]string, len(stencil.ColumnOrder)),
+		Widths:  stencil.Widths,
+	}
+	for i, d := range s.formatMaps(stencil.Formats, data) {
+		table.Rows[i] = mapToSliceInColumnOrder(d, stencil.ColumnOrder)
 	}
-	if headerSlices, ok := createHeaderSlices(stencil, data); ok {
-		coloredSlices = append(headerSlices, coloredSlices...)
+	for i, column := range stencil.ColumnOrder {
+		table.Colors[i] = stencil.Colors[column]
 	}
-	return coloredSlices, nil
+	return table, nil
+}
 
+// UseListStencil takes the ID of a List Stencil and a slice of "item" maps with
+// string key/values. It returns an error if it can't find a Stencil with the
+// passed ID or template interpolation fails. It applies the List Stencil's
+// template to each item map and returns the results in the same order.
+func (s *Stenciller) UseListStencil(id string, items []map[string]string) ([]string, error) {
+	stencil, err := s.findListStencil(id)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]string, len(items))
+	template := s.localizeTemplate(stencil.Template, stencil.Localized)
+	for i, item := range items {
+		coloredData := s.colorMap(stencil.Colors, s.formatMap(stencil.Formats, item))
+		results[i], err = s.interpolate(stencil.ID, stencil.templates, template, coloredData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// localizeTemplate returns the variant of a template for the current locale,
+// or the template translated by the locale's message catalog
+func (s *Stenciller) localizeTemplate(template string, variants map[string]string) string {
+	l := s.currentLocale()
+	if l == nil {
+		return template
+	}
+	for _, tag := range l.Candidates() {
+		for variantTag, variant := range variants {
+			if locale.Normalize(variantTag) == tag {
+				return variant
+			}
+		}
+	}
+	return s.Message(template)
+}
+
+// localizeTable returns a copy of a Table Stencil with its headers and footer
+// label localized for the current locale
+func (s *Stenciller) localizeTable(stencil *TableStencil) *TableStencil {
+	l := s.currentLocale()
+	if l == nil {
+		return stencil
+	}
+	localized := *stencil
+	localized.FooterLabel = s.Message(stencil.FooterLabel)
+	for _, tag := range l.Candidates() {
+		for variantTag, headers := range stencil.LocalizedHeaders {
+			if locale.Normalize(variantTag) == tag {
+				localized.Headers = headers
+				return &localized
+			}
+		}
+	}
+	if stencil.Headers != nil {
+		localized.Headers = make([]string, len(stencil.Headers))
+		for i, header := range stencil.Headers {
+			localized.Headers[i] = s.Message(header)
+		}
+	}
+	return &localized
 }
 
 func (s *Stenciller) findTemplateStencil(id string) (*TemplateStencil, error) {
+	s.mu.RLock()
 	for _, stencil := range s.templateStencils {
 		if stencil.ID == id {
+			s.mu.RUnlock()
 			return stencil, nil
 		}
 	}
+	s.mu.RUnlock()
+	if s.parent != nil {
+		return s.parent.findTemplateStencil(id)
+	}
 	return nil, fmt.Errorf("Unable to find template stencil with id of %v", id)
 }
 
 func (s *Stenciller) findTableStencil(id string) (*TableStencil, error) {
+	s.mu.RLock()
 	for _, stencil := range s.tableStencils {
 		if stencil.ID == id {
+			s.mu.RUnlock()
 			return stencil, nil
 		}
 	}
+	s.mu.RUnlock()
+	if s.parent != nil {
+		return s.parent.findTableStencil(id)
+	}
 	return nil, fmt.Errorf("Unable to find table stencil with id of %v", id)
 }
 
+func (s *Stenciller) findListStencil(id string) (*ListStencil, error) {
+	s.mu.RLock()
+	for _, stencil := range s.listStencils {
+		if stencil.ID == id {
+			s.mu.RUnlock()
+			return stencil, nil
+		}
+	}
+	s.mu.RUnlock()
+	if s.parent != nil {
+		return s.parent.findListStencil(id)
+	}
+	return nil, fmt.Errorf("Unable to find list stencil with id of %v", id)
+}
+
 func (s *Stenciller) colorMap(colors map[string]string, data map[string]string) map[string]string {
 	colored := make(map[string]string, len(data))
 	for key, val := range data {
@@ -171,6 +536,41 @@ func (s *Stenciller) colorValue(val, col string) string {
 	return val
 }
 
+// formatMap applies the passed value formats to a copy of the data map. A value
+// that can't be formatted is left as it is.
+func (s *Stenciller) formatMap(formats map[string]*humanize.Format, data map[string]string) map[string]string {
+	if len(formats) == 0 {
+		return data
+	}
+	formatted := make(map[string]string, len(data))
+	for key, val := range data {
+		formatted[key] = val
+	}
+	l := s.currentLocale()
+	for key, format := range formats {
+		val, err := format.ApplyLocale(data[key], l)
+		if err != nil {
+			log.Debug().Err(err).Msgf("Unable to format [value=%v] of [key=%v]", data[key], key)
+			continue
+		}
+		if _, ok := data[key]; ok || val != "" {
+			formatted[key] = val
+		}
+	}
+	return formatted
+}
+
+func (s *Stenciller) formatMaps(formats map[string]*humanize.Format, data []map[string]string) []map[string]string {
+	if len(formats) == 0 {
+		return data
+	}
+	formatted := make([]map[string]string, len(data))
+	for i, d := range data {
+		formatted[i] = s.formatMap(formats, d)
+	}
+	return formatted
+}
+
 func mapToSliceInColumnOrder(mapRow map[string]string, columnOrder []string) []string {
 	sliceRow := make([]string, len(columnOrder))
 	for key, value := range mapRow {
@@ -184,21 +584,23 @@ func mapToSliceInColumnOrder(mapRow map[string]string, columnOrder []string) []s
 	return sliceRow
 }
 
-func createHeaderSlices(stencil *TableStencil, dataMaps []map[string]string) (_ [][]string, ok bool) {
+func createHeaderSlices(stencil *TableStencil, dataMaps []map[string]string, footer []string) (_ [][]string, ok bool) {
 	if len(stencil.Headers) == 0 {
 		return nil, false
 	}
-	dataSlices := [][]string{}
+	return [][]string{stencil.Headers, createDivRowFor(stencil, dataMaps, footer)}, true
+}
+
+// createDivRowFor creates a divider row sized to the widest of the headers,
+// uncolored data and footer of each column
+func createDivRowFor(stencil *TableStencil, dataMaps []map[string]string, footer []string) []string {
+	dataSlices := [][]string{stencil.Headers, footer}
 	for _, m := range dataMaps {
 		dataSlices = append(dataSlices, mapToSliceInColumnOrder(m, stencil.ColumnOrder))
 	}
-	dataSlices = append([][]string{stencil.Headers}, dataSlices...)
-	colWidths := getColWidths(dataSlices)
-	divRow := createDivRow(colWidths)
-	return [][]string{stencil.Headers, divRow}, true
+	return createDivRow(getColWidths(dataSlices))
 }
 
-
 func createDivRow(colWidths map[int]int) []string {
 	divRow := make([]string, len(colWidths))
 	for col, width := range colWidths {
@@ -217,8 +619,10 @@ func getColWidths(rows [][]string) map[int]int {
 	widths := make(map[int]int, maxCols)
 	for _, row := range rows {
 		for col, elem := range row {
-			if len(elem) > widths[col] {
-				widths[col] = len(elem)
+			for _, line := range strings.Split(elem, "\n") {
+				if n := formatter.Width(line); n > widths[col] {
+					widths[col] = n
+				}
 			}
 		}
 	}
@@ -233,3 +637,36 @@ func indexOf(elem string, data []string) (int, error) {
 	}
 	return 0, fmt.Errorf("Unable to find index of element %v", elem)
 }
+
+// hasTemplateStencil returns whether a Template Stencil with the passed ID has been
+// added. The caller must hold the lock.
+func (s *Stenciller) hasTemplateStencil(id string) bool {
+	for _, stencil := range s.templateStencils {
+		if stencil.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTableStencil returns whether a Table Stencil with the passed ID has been
+// added. The caller must hold the lock.
+func (s *Stenciller) hasTableStencil(id string) bool {
+	for _, stencil := range s.tableStencils {
+		if stencil.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// hasListStencil returns whether a List Stencil with the passed ID has been
+// added. The caller must hold the lock.
+func (s *Stenciller) hasListStencil(id string) bool {
+	for _, stencil := range s.listStencils {
+		if stencil.ID == id {
+			return true
+		}
+	}
+	return false
+}
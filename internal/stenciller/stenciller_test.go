@@ -332,6 +332,93 @@ func (suite *StencillerSuite) TestTableStencilWithColumnOrderNotInData() {
 	suite.Equal(expected, actual)
 }
 
+func (suite *StencillerSuite) TestTableStencilWithMultiLineCell() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"key1"},
+		Headers:     []string{"h1"},
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	data := []map[string]string{{"key1": "line one\nline 2"}}
+	expected := [][]string{
+		{"h1"},
+		{"--------"},
+		{"line one\nline 2"},
+	}
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+}
+
+func (suite *StencillerSuite) TestTableStencilWithWideAndColoredCells() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"key1", "key2"},
+		Headers:     []string{"h1", "h2"},
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	data := []map[string]string{{"key1": "日本語", "key2": "\u001b[31mred\u001b[0m"}}
+	expected := [][]string{
+		{"h1", "h2"},
+		{"------", "---"},
+		{"日本語", "\u001b[31mred\u001b[0m"},
+	}
+	actual, err := suite.Stenciller.UseTableStencil(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+}
+
+func (suite *StencillerSuite) TestTableData() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		Colors:      map[string]string{"key2": "red"},
+		ColumnOrder: []string{"key1", "key2"},
+		Headers:     []string{"header1", "header2"},
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	data := []map[string]string{{
+		"key1": "value1a",
+		"key2": "value2a",
+	}, {
+		"key2": "value2b",
+	}}
+	expected := &Table{
+		Headers: []string{"header1", "header2"},
+		Columns: []string{"key1", "key2"},
+		Rows: [][]string{
+			{"value1a", "value2a"},
+			{"", "value2b"},
+		},
+		Colors: []string{"", "red"},
+	}
+	actual, err := suite.Stenciller.TableData(stencil.ID, data)
+	suite.NoError(err)
+	suite.Equal(expected, actual)
+	suite.Colorer.AssertNotCalled(suite.T(), "Color", mock.Anything, mock.Anything)
+}
+
+func (suite *StencillerSuite) TestUseTableStencilRow() {
+	stencil := &TableStencil{
+		ID:          "test-id",
+		Colors:      map[string]string{"key2": "red"},
+		ColumnOrder: []string{"key1", "key2"},
+		Headers:     []string{"header1", "header2"},
+	}
+	suite.Stenciller.AddTableStencil(stencil)
+	suite.Colorer.On("Color", "value2", "red").Return("red value2", true)
+	actual, err := suite.Stenciller.UseTableStencilRow(stencil.ID, map[string]string{
+		"key1": "value1",
+		"key2": "value2",
+	})
+	suite.NoError(err)
+	suite.Equal([]string{"value1", "red value2"}, actual)
+}
+
+func (suite *StencillerSuite) TestUseTableStencilRowNotFound() {
+	_, err := suite.Stenciller.UseTableStencilRow("missing", nil)
+	suite.Error(err)
+}
+
 func (suite *StencillerSuite) TestFindTmplStencil() {
 	stencil1 := &TemplateStencil{ID: "1"}
 	stencil2 := &TemplateStencil{ID: "2"}
@@ -421,6 +508,70 @@ func (suite *StencillerSuite) TestColorDataWithNonExistantColor() {
 	suite.Equal(expected, actual)
 }
 
+func (suite *StencillerSuite) TestAddListStencil() {
+	suite.Empty(suite.Stenciller.listStencils)
+	stencil := &ListStencil{
+		ID:       "test-id",
+		Template: "{{ .name }}",
+		Colors:   map[string]string{"name": "red"},
+	}
+	err := suite.Stenciller.AddListStencil(stencil)
+	suite.NoError(err)
+	suite.Len(suite.Stenciller.listStencils, 1)
+}
+
+func (suite *StencillerSuite) TestAddListStencilWithExistingID() {
+	stencil := &ListStencil{ID: "test-id", Template: "{{ .name }}"}
+	suite.Stenciller.listStencils = append(suite.Stenciller.listStencils, stencil)
+	err := suite.Stenciller.AddListStencil(&ListStencil{ID: stencil.ID})
+	suite.EqualError(err, "List Stencil with ID test-id already exists")
+}
+
+func (suite *StencillerSuite) TestAddListStencilWithEmptyID() {
+	err := suite.Stenciller.AddListStencil(&ListStencil{})
+	suite.EqualError(err, "Stencil ID may not be empty")
+}
+
+func (suite *StencillerSuite) TestListStencil() {
+	stencil := &ListStencil{
+		ID:       "test-id",
+		Template: "{{ .name }} is {{ .status }}",
+		Colors:   map[string]string{"status": "red"},
+	}
+	suite.Stenciller.AddListStencil(stencil)
+	items := []map[string]string{
+		{"name": "first", "status": "up"},
+		{"name": "second", "status": "down"},
+	}
+	suite.Colorer.On("Color", "up", "red").Return("redUp", true)
+	suite.Colorer.On("Color", "down", "red").Return("redDown", true)
+	actual, err := suite.Stenciller.UseListStencil(stencil.ID, items)
+	suite.NoError(err)
+	suite.Equal([]string{"first is redUp", "second is redDown"}, actual)
+}
+
+func (suite *StencillerSuite) TestNotFindListStencil() {
+	actual, err := suite.Stenciller.UseListStencil("missing", nil)
+	suite.EqualError(err, "Unable to find list stencil with id of missing")
+	suite.Nil(actual)
+}
+
+func (suite *StencillerSuite) TestChildFindsParentStencils() {
+	parentStencil := &TemplateStencil{ID: "parent"}
+	suite.Stenciller.AddTemplateStencil(parentStencil)
+	child := suite.Stenciller.Child()
+	childStencil := &TemplateStencil{ID: "child"}
+	suite.NoError(child.AddTemplateStencil(childStencil))
+	actual, err := child.findTemplateStencil("parent")
+	suite.NoError(err)
+	suite.Equal(parentStencil, actual)
+	actual, err = child.findTemplateStencil("child")
+	suite.NoError(err)
+	suite.Equal(childStencil, actual)
+	_, err = suite.Stenciller.findTemplateStencil("child")
+	suite.EqualError(err, "Unable to find template stencil with id of child")
+}
+
 func TestStencillerSuite(t *testing.T) {
 	suite.Run(t, new(StencillerSuite))
 }
@@ -0,0 +1,136 @@
+package stenciller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/locale"
+	"github.com/tomguerney/printer/internal/templater"
+)
+
+// SetMissingKey sets the policy for keys of Template and List Stencils that
+// are missing from a data map, which is one of templater.MissingEmpty (the
+// default), templater.MissingKeep or templater.MissingError
+func (s *Stenciller) SetMissingKey(policy string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.missingKey = policy
+}
+
+// missingKeyPolicy returns the policy set with SetMissingKey
+func (s *Stenciller) missingKeyPolicy() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.missingKey
+}
+
+// interpolate applies a data map to a template of a stencil bound to the
+// partials
+func (s *Stenciller) interpolate(id string, parsed map[string]*templater.Template, text string, data map[string]string) (string, error) {
+	t, err := s.boundTemplate(id, parsed, text)
+	if err != nil {
+		return "", err
+	}
+	return t.Execute(data, &templater.Options{Funcs: s.funcs(), Missing: s.missingKeyPolicy()})
+}
+
+// boundTemplate returns a template of a stencil bound to the partials, using
+// the template parsed when the stencil was added, or parsing it if it wasn't,
+// such as when it has been translated by a message catalog. Bound templates
+// are cached until the partials change, so each is parsed and bound once.
+func (s *Stenciller) boundTemplate(id string, parsed map[string]*templater.Template, text string) (*templater.Template, error) {
+	key := templateKey{id: id, text: text}
+	version := s.partialsVersion()
+	s.cacheMu.Lock()
+	if s.cacheVersion != version {
+		s.cache, s.cacheVersion = nil, version
+	}
+	t, ok := s.cache[key]
+	s.cacheMu.Unlock()
+	if ok {
+		return t, nil
+	}
+	t, ok = parsed[text]
+	if !ok {
+		var err error
+		if t, err = templater.Parse(id, text, s.funcs()); err != nil {
+			return nil, err
+		}
+	}
+	bound, err := t.Bind(s.allPartials())
+	if err != nil {
+		return nil, err
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if s.cacheVersion == version {
+		if s.cache == nil {
+			s.cache = map[templateKey]*templater.Template{}
+		}
+		s.cache[key] = bound
+	}
+	return bound, nil
+}
+
+// funcs returns the functions available to templates:
+//
+//	number    localizes a number, e.g. {{ number .count }} is "1,234" in English
+//	plural    returns the form of a number's plural category in the locale,
+//	          passed as "one" and "other" forms, or "one", "few", "many" and
+//	          "other" forms, with any "#" replaced by the localized number, e.g.
+//	          {{ plural .count "# file" "# files" }}
+//	translate translates text by the message catalog of the locale
+//
+// Colors of values are ignored when they are parsed as numbers.
+func (s *Stenciller) funcs() template.FuncMap {
+	return template.FuncMap{
+		"number":    s.number,
+		"plural":    s.plural,
+		"translate": s.Message,
+	}
+}
+
+func (s *Stenciller) loc() *locale.Locale {
+	if l := s.currentLocale(); l != nil {
+		return l
+	}
+	return locale.Default
+}
+
+// number localizes a number, keeping any color it has
+func (s *Stenciller) number(value string) string {
+	plain := formatter.StripAnsi(value)
+	if _, err := strconv.ParseFloat(plain, 64); err != nil {
+		return value
+	}
+	return strings.Replace(value, plain, s.loc().Number(plain, ""), 1)
+}
+
+func (s *Stenciller) plural(value string, forms ...string) (string, error) {
+	n, err := strconv.ParseFloat(formatter.StripAnsi(value), 64)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse %q as a number", value)
+	}
+	var form string
+	switch len(forms) {
+	case 2:
+		form = forms[1]
+		if s.loc().Plural(n) == locale.One {
+			form = forms[0]
+		}
+	case 4:
+		categories := map[string]string{
+			locale.One:   forms[0],
+			locale.Few:   forms[1],
+			locale.Many:  forms[2],
+			locale.Other: forms[3],
+		}
+		form = categories[s.loc().Plural(n)]
+	default:
+		return "", fmt.Errorf("plural takes 2 or 4 forms, not %v", len(forms))
+	}
+	return strings.Replace(s.Message(form), "#", s.number(value), -1), nil
+}
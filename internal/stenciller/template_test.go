@@ -0,0 +1,102 @@
+package stenciller
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/templater"
+)
+
+func (suite *StencillerSuite) TestAddTemplateStencilWithInvalidTemplate() {
+	err := suite.Stenciller.AddTemplateStencil(&TemplateStencil{ID: "test-id", Template: "{{ .name "})
+	suite.EqualError(err, "Invalid template stencil test-id: template, line 1: unclosed action")
+	suite.Empty(suite.Stenciller.templateStencils)
+	err = suite.Stenciller.AddListStencil(&ListStencil{
+		ID:        "test-id",
+		Template:  "{{ .name }}",
+		Localized: map[string]string{"de": "{{ .name }"},
+	})
+	suite.EqualError(err, `Invalid list stencil test-id: template (de), line 1: unexpected "}" in operand`)
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithMissingKey() {
+	suite.NoError(suite.Stenciller.AddTemplateStencil(&TemplateStencil{ID: "test-id", Template: "{{ .name }} is {{ .status }}"}))
+	data := map[string]string{"name": "web"}
+	actual, err := suite.Stenciller.UseTemplateStencil("test-id", data)
+	suite.NoError(err)
+	suite.Equal("web is ", actual)
+	suite.Stenciller.SetMissingKey(templater.MissingKeep)
+	actual, err = suite.Stenciller.Child().UseTemplateStencil("test-id", data)
+	suite.NoError(err)
+	suite.Equal("web is {{.status}}", actual)
+	suite.Stenciller.SetMissingKey(templater.MissingError)
+	_, err = suite.Stenciller.UseTemplateStencil("test-id", data)
+	suite.EqualError(err, `template test-id: line 1, column 19: <.status>: map has no entry for key "status"`)
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithFuncs() {
+	suite.Colorer.On("Color", mock.Anything, mock.Anything).Return("", false)
+	suite.NoError(suite.Stenciller.AddTemplateStencil(&TemplateStencil{
+		ID:       "test-id",
+		Template: `{{ translate "Found" }} {{ plural .count "# file" "# files" }} of {{ number .total }}`,
+	}))
+	tests := []struct {
+		tag      string
+		count    string
+		expected string
+	}{
+		{"", "1", "Found 1 file of 12,345"},
+		{"", "1200", "Found 1,200 files of 12,345"},
+		{"de", "1200", "Gefunden 1.200 Dateien of 12.345"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.count+tt.tag, func() {
+			if tt.tag != "" {
+				suite.Stenciller.AddCatalog(tt.tag, map[string]string{"Found": "Gefunden", "# files": "# Dateien"})
+				suite.setLocale(tt.tag)
+			}
+			actual, err := suite.Stenciller.UseTemplateStencil("test-id", map[string]string{"count": tt.count, "total": "12345"})
+			suite.NoError(err)
+			suite.Equal(tt.expected, actual)
+		})
+	}
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithFuncsAndColors() {
+	suite.Colorer.On("Color", "1200", "red").Return("\u001b[31m1200\u001b[0m", true)
+	suite.NoError(suite.Stenciller.AddTemplateStencil(&TemplateStencil{
+		ID:       "test-id",
+		Template: `{{ plural .count "# file" "# files" }}, {{ number .count }}`,
+		Colors:   map[string]string{"count": "red"},
+	}))
+	actual, err := suite.Stenciller.UseTemplateStencil("test-id", map[string]string{"count": "1200"})
+	suite.NoError(err)
+	suite.Equal("\u001b[31m1,200\u001b[0m files, \u001b[31m1,200\u001b[0m", actual)
+}
+
+func (suite *StencillerSuite) TestTemplateStencilWithInvalidPlural() {
+	suite.NoError(suite.Stenciller.AddListStencil(&ListStencil{ID: "test-id", Template: `{{ plural .count "file" }}`}))
+	_, err := suite.Stenciller.UseListStencil("test-id", []map[string]string{{"count": "2"}})
+	suite.EqualError(err, "template test-id: line 1, column 4: <plural .count \"file\">: error calling plural: plural takes 2 or 4 forms, not 1")
+}
+
+func (suite *StencillerSuite) TestBoundTemplatesAreCached() {
+	text := `{{ template "name" . }} found`
+	suite.NoError(suite.Stenciller.AddPartial("name", "[{{ .name }}]"))
+	suite.NoError(suite.Stenciller.AddTemplateStencil(&TemplateStencil{ID: "test-id", Template: text}))
+	suite.Stenciller.AddCatalog("de", map[string]string{text: `{{ template "name" . }} gefunden`})
+	suite.setLocale("de")
+	stencil, err := suite.Stenciller.findTemplateStencil("test-id")
+	suite.NoError(err)
+	translated := suite.Stenciller.localizeTemplate(stencil.Template, stencil.Localized)
+	first, err := suite.Stenciller.boundTemplate(stencil.ID, stencil.templates, translated)
+	suite.NoError(err)
+	second, err := suite.Stenciller.boundTemplate(stencil.ID, stencil.templates, translated)
+	suite.NoError(err)
+	suite.Same(first, second)
+	suite.NoError(suite.Stenciller.AddPartial("other", "{{ .other }}"))
+	third, err := suite.Stenciller.boundTemplate(stencil.ID, stencil.templates, translated)
+	suite.NoError(err)
+	suite.NotSame(first, third)
+	actual, err := suite.Stenciller.UseTemplateStencil("test-id", map[string]string{"name": "web"})
+	suite.NoError(err)
+	suite.Equal("[web] gefunden", actual)
+}
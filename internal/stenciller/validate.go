@@ -0,0 +1,279 @@
+package stenciller
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	c "github.com/tomguerney/printer/internal/colorer"
+	"github.com/tomguerney/printer/internal/humanize"
+	"github.com/tomguerney/printer/internal/templater"
+)
+
+// ValidationError is an error listing every problem found with a Stencil when
+// it was added
+type ValidationError struct {
+	Kind     string
+	ID       string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("Invalid %v stencil %v: %v", e.Kind, e.ID, strings.Join(e.Problems, "; "))
+}
+
+// SetStrict sets whether Stencils with problems are rejected. When lenient (the
+// default), problems are logged as warnings and the Stencil is added anyway,
+// unless its template can't be parsed or it extends itself. When strict, adding
+// a Stencil with any problem returns a ValidationError.
+func (s *Stenciller) SetStrict(strict bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strict = strict
+}
+
+// isStrict returns whether the Stenciller is strict, as set with SetStrict
+func (s *Stenciller) isStrict() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.strict
+}
+
+// validation collects the problems with a Stencil
+type validation struct {
+	kind     string
+	id       string
+	problems []string
+	fatal    bool
+}
+
+func (v *validation) add(format string, a ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, a...))
+}
+
+// template adds a problem for an error parsing a template of the Stencil,
+// which the Stencil can't be added with
+func (v *validation) template(err error) {
+	v.fatal = true
+	var e *templater.Error
+	if !errors.As(err, &e) {
+		v.add("%v", err)
+		return
+	}
+	where := "template"
+	if e.Name != v.id {
+		where += strings.TrimPrefix(e.Name, v.id)
+	}
+	switch {
+	case e.Column > 0:
+		v.add("%v, line %v, column %v: %v", where, e.Line, e.Column, e.Message)
+	case e.Line > 0:
+		v.add("%v, line %v: %v", where, e.Line, e.Message)
+	default:
+		v.add("%v: %v", where, e.Message)
+	}
+}
+
+// colors adds a problem for each color that doesn't exist, and for each key
+// that isn't one of the passed keys
+func (v *validation) colors(colors map[string]string, keys []string, what string) {
+	for _, key := range sortedKeys(colors) {
+		if !contains(keys, key) {
+			v.add("color for %v %v, which isn't used", what, key)
+		}
+		if !c.Exists(colors[key]) {
+			v.add("unknown color %v for %v %v", colors[key], what, key)
+		}
+	}
+}
+
+// formats adds a problem for each invalid format, and for each key that isn't
+// one of the passed keys
+func (v *validation) formats(formats map[string]*humanize.Format, keys []string, what string) {
+	for _, key := range sortedKeys(formats) {
+		if !contains(keys, key) {
+			v.add("format for %v %v, which isn't used", what, key)
+		}
+		if err := formats[key].Validate(); err != nil {
+			v.add("invalid format for %v %v: %v", what, key, err)
+		}
+	}
+}
+
+// policy adds a problem if a data policy is unknown
+func (v *validation) policy(policy, what string) {
+	switch policy {
+	case "", PolicyIgnore, PolicyWarn, PolicyError:
+	default:
+		v.add("unknown policy %v for %v", policy, what)
+	}
+}
+
+// result returns a ValidationError if the Stencil has problems and can't be
+// added, and otherwise logs any problems as warnings
+func (v *validation) result(strict bool) error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	err := &ValidationError{Kind: v.kind, ID: v.id, Problems: v.problems}
+	if strict || v.fatal {
+		return err
+	}
+	log.Warn().Msg(err.Error())
+	return nil
+}
+
+func (s *Stenciller) validateTemplateStencil(stencil *TemplateStencil) (map[string]*templater.Template, error) {
+	v := &validation{kind: "template", id: stencil.ID}
+	templates, keys := s.validateTemplates(v, stencil.Template, stencil.Localized)
+	v.colors(stencil.Colors, keys, "key")
+	v.formats(stencil.Formats, keys, "key")
+	return templates, v.result(s.isStrict())
+}
+
+func (s *Stenciller) validateListStencil(stencil *ListStencil) (map[string]*templater.Template, error) {
+	v := &validation{kind: "list", id: stencil.ID}
+	templates, keys := s.validateTemplates(v, stencil.Template, stencil.Localized)
+	v.colors(stencil.Colors, keys, "key")
+	v.formats(stencil.Formats, keys, "key")
+	return templates, v.result(s.isStrict())
+}
+
+// validateTemplates parses a template and its localized variants, and returns
+// them and the keys they and the partials they include use. It adds a problem
+// for each partial they include that hasn't been added.
+func (s *Stenciller) validateTemplates(v *validation, text string, localized map[string]string) (map[string]*templater.Template, []string) {
+	templates := map[string]*templater.Template{}
+	keys := []string{}
+	partials := s.allPartials()
+	included := map[string]bool{}
+	var include func(t *templater.Template)
+	include = func(t *templater.Template) {
+		keys = append(keys, t.Fields()...)
+		for _, name := range t.Partials() {
+			if included[name] {
+				continue
+			}
+			included[name] = true
+			partial, ok := partials[name]
+			if !ok {
+				v.add("unknown partial %v", name)
+				continue
+			}
+			include(partial)
+		}
+	}
+	parse := func(name, text string) {
+		t, err := templater.Parse(name, text, s.funcs())
+		if err != nil {
+			v.template(err)
+			return
+		}
+		templates[text] = t
+		include(t)
+	}
+	parse(v.id, text)
+	for _, tag := range sortedKeys(localized) {
+		parse(fmt.Sprintf("%v (%v)", v.id, tag), localized[tag])
+	}
+	return templates, keys
+}
+
+func (s *Stenciller) validateTableStencil(stencil *TableStencil) error {
+	v := &validation{kind: "table", id: stencil.ID}
+	if stencil.Extends == "" && len(stencil.RemoveColumns) > 0 {
+		v.add("removed columns without extending a stencil")
+	}
+	if stencil.Extends != "" {
+		lineage, missing, cycle := s.lineage(stencil)
+		switch {
+		case missing != "":
+			v.add("unknown base stencil %v", missing)
+			return v.result(s.isStrict())
+		case cycle:
+			v.add("inheritance cycle: %v", describeLineage(lineage))
+			v.fatal = true
+			return v.result(s.isStrict())
+		}
+		base := lineage[len(lineage)-1]
+		for i := len(lineage) - 2; i >= 1; i-- {
+			base = extendTable(base, lineage[i])
+		}
+		inherited := base.ColumnOrder
+		if len(stencil.ColumnOrder) > 0 {
+			inherited = stencil.ColumnOrder
+		}
+		for _, column := range stencil.RemoveColumns {
+			if !contains(inherited, column) {
+				v.add("removed column %v, which isn't inherited", column)
+			}
+		}
+		stencil = extendTable(base, stencil)
+	}
+	columns := stencil.ColumnOrder
+	seen := map[string]bool{}
+	for _, column := range columns {
+		if seen[column] {
+			v.add("duplicate column %v", column)
+		}
+		seen[column] = true
+	}
+	if len(stencil.Headers) > 0 && len(stencil.Headers) != len(columns) {
+		v.add("%v headers for %v columns", len(stencil.Headers), len(columns))
+	}
+	for _, tag := range sortedKeys(stencil.LocalizedHeaders) {
+		if headers := stencil.LocalizedHeaders[tag]; len(headers) != len(columns) {
+			v.add("%v headers for locale %v for %v columns", len(headers), tag, len(columns))
+		}
+	}
+	if len(stencil.Widths) > 0 && len(stencil.Widths) != len(columns) {
+		v.add("%v widths for %v columns", len(stencil.Widths), len(columns))
+	}
+	v.colors(stencil.Colors, columns, "column")
+	v.formats(stencil.Formats, columns, "column")
+	for _, key := range sortedKeys(stencil.Footer) {
+		if !contains(columns, key) {
+			v.add("footer for column %v, which isn't used", key)
+		}
+		switch stencil.Footer[key] {
+		case Sum, Avg, Min, Max, Count:
+		default:
+			v.add("unknown aggregate %v for column %v", stencil.Footer[key], key)
+		}
+	}
+	for _, key := range sortedKeys(stencil.Defaults) {
+		if !contains(columns, key) {
+			v.add("default for column %v, which isn't used", key)
+		}
+	}
+	v.policy(stencil.UnknownKeys, "unknown keys")
+	v.policy(stencil.MissingKeys, "missing keys")
+	for _, key := range sortedKeys(stencil.FooterFuncs) {
+		if !contains(columns, key) {
+			v.add("footer function for column %v, which isn't used", key)
+		}
+	}
+	return v.result(s.isStrict())
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
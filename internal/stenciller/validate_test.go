@@ -0,0 +1,76 @@
+package stenciller
+
+import (
+	"errors"
+
+	"github.com/tomguerney/printer/internal/humanize"
+)
+
+func (suite *StencillerSuite) TestAddTableStencilWithProblems() {
+	suite.Stenciller.SetStrict(true)
+	err := suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:               "test-id",
+		ColumnOrder:      []string{"name", "cpu", "name"},
+		Headers:          []string{"Name", "CPU"},
+		LocalizedHeaders: map[string][]string{"de": {"Name", "CPU", "Name"}, "fr": {"Nom"}},
+		Widths:           []int{10},
+		Colors:           map[string]string{"cpu": "purple", "status": "red"},
+		Formats:          map[string]*humanize.Format{"size": {Type: humanize.Bytes}},
+		Footer:           map[string]string{"cpu": "median", "mem": Sum},
+		Defaults:         map[string]string{"zone": "-"},
+		UnknownKeys:      "complain",
+		FooterFuncs: map[string]func([]string) (string, error){
+			"disk": func(values []string) (string, error) { return "", nil },
+		},
+	})
+	var validationErr *ValidationError
+	suite.True(errors.As(err, &validationErr))
+	suite.Equal("table", validationErr.Kind)
+	suite.Equal("test-id", validationErr.ID)
+	suite.Equal([]string{
+		"duplicate column name",
+		"2 headers for 3 columns",
+		"1 headers for locale fr for 3 columns",
+		"1 widths for 3 columns",
+		"unknown color purple for column cpu",
+		"color for column status, which isn't used",
+		"format for column size, which isn't used",
+		"unknown aggregate median for column cpu",
+		"footer for column mem, which isn't used",
+		"default for column zone, which isn't used",
+		"unknown policy complain for unknown keys",
+		"footer function for column disk, which isn't used",
+	}, validationErr.Problems)
+	suite.Empty(suite.Stenciller.tableStencils)
+}
+
+func (suite *StencillerSuite) TestAddTemplateStencilWithProblems() {
+	suite.Stenciller.SetStrict(true)
+	err := suite.Stenciller.AddTemplateStencil(&TemplateStencil{
+		ID:       "test-id",
+		Template: "{{ .name }} is {{ .status }}",
+		Colors:   map[string]string{"name": "bold", "state": "green", "status": "bright"},
+	})
+	suite.EqualError(err, "Invalid template stencil test-id: color for key state, which isn't used; unknown color bright for key status")
+	suite.Empty(suite.Stenciller.templateStencils)
+}
+
+func (suite *StencillerSuite) TestAddStencilWhenLenient() {
+	suite.NoError(suite.Stenciller.AddTableStencil(&TableStencil{
+		ID:          "test-id",
+		ColumnOrder: []string{"name"},
+		Headers:     []string{"Name", "Status"},
+	}))
+	suite.Len(suite.Stenciller.tableStencils, 1)
+	suite.NoError(suite.Stenciller.AddListStencil(&ListStencil{
+		ID:       "test-id",
+		Template: "{{ .name }}",
+		Colors:   map[string]string{"name": "purple"},
+	}))
+	suite.Len(suite.Stenciller.listStencils, 1)
+	err := suite.Stenciller.AddTemplateStencil(&TemplateStencil{ID: "test-id", Template: "{{ .name"})
+	suite.EqualError(err, "Invalid template stencil test-id: template, line 1: unclosed action")
+	suite.Empty(suite.Stenciller.templateStencils)
+	suite.Stenciller.SetStrict(true)
+	suite.True(suite.Stenciller.Child().strict)
+}
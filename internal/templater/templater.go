@@ -0,0 +1,249 @@
+package templater
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// Missing key policies
+const (
+	MissingError = "error"
+	MissingEmpty = "empty"
+	MissingKeep  = "keep"
+)
+
+// Template is a parsed template that is applied to "data" maps of string
+// key/value pairs
+type Template struct {
+	name          string
+	tmpl          *template.Template
+	fields        []string
+	partials      []string
+	partialFields []string
+}
+
+// Options are the options a template is executed with. Funcs replace the
+// functions the template was parsed with. Missing is the missing key policy,
+// which sets what a key of the template that isn't in the data map is replaced
+// with: MissingEmpty (the default) replaces it with an empty string,
+// MissingKeep keeps its placeholder, e.g. "{{.name}}", and MissingError
+// returns an error.
+type Options struct {
+	Funcs   template.FuncMap
+	Missing string
+}
+
+// Error is an error parsing or executing a template, with the name of the
+// template and the position in it of the error. Column is 0 if the position
+// of the error within its line isn't known.
+type Error struct {
+	Name    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("template %v: line %v, column %v: %v", e.Name, e.Line, e.Column, e.Message)
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("template %v: line %v: %v", e.Name, e.Line, e.Message)
+	}
+	return fmt.Sprintf("template %v: %v", e.Name, e.Message)
+}
+
+// Parse parses the passed text as per the "text/template" package with the
+// passed functions. The functions can be replaced by others of the same names
+// when the template is executed. It returns an *Error if the text can't be
+// parsed.
+func Parse(name, text string, funcs template.FuncMap) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, newError(name, err)
+	}
+	t := &Template{name: name, tmpl: tmpl}
+	defined := map[string]bool{}
+	for _, tmpl := range tmpl.Templates() {
+		defined[tmpl.Name()] = true
+	}
+	for _, tmpl := range tmpl.Templates() {
+		if tmpl.Tree == nil {
+			continue
+		}
+		for _, node := range walk(tmpl.Tree.Root, nil) {
+			switch n := node.(type) {
+			case *parse.FieldNode:
+				t.fields = append(t.fields, n.Ident[0])
+			case *parse.TemplateNode:
+				if !defined[n.Name] {
+					t.partials = append(t.partials, n.Name)
+				}
+			}
+		}
+	}
+	return t, nil
+}
+
+// Bind returns a copy of the template that can include the passed partials by
+// name, e.g. {{ template "header" . }}. Partials are bound once, rather than
+// each time the template is executed. It returns an *Error if a partial can't
+// be bound.
+func (t *Template) Bind(partials map[string]*Template) (*Template, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return nil, newError(t.name, err)
+	}
+	bound := &Template{name: t.name, tmpl: tmpl, fields: t.fields, partials: t.partials}
+	for name, partial := range partials {
+		if tmpl.Lookup(name) == nil && partial.tmpl.Tree != nil {
+			if _, err := tmpl.AddParseTree(name, partial.tmpl.Tree); err != nil {
+				return nil, newError(t.name, err)
+			}
+			bound.partialFields = append(bound.partialFields, partial.fields...)
+		}
+	}
+	return bound, nil
+}
+
+// Execute applies the passed data map to the template as per the passed
+// Options and returns the result. If opts is nil the default Options are used.
+// It returns an *Error if execution fails.
+func (t *Template) Execute(data map[string]string, opts *Options) (string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", newError(t.name, err)
+	}
+	if opts.Funcs != nil {
+		tmpl.Funcs(opts.Funcs)
+	}
+	switch opts.Missing {
+	case MissingError:
+		tmpl.Option("missingkey=error")
+	case MissingKeep:
+		data = t.keep(data)
+	default:
+		tmpl.Option("missingkey=zero")
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", newError(t.name, err)
+	}
+	return b.String(), nil
+}
+
+// Fields returns the keys of the data map the template uses, e.g. "name" for
+// "{{ .name }}", not including those used by the partials it includes
+func (t *Template) Fields() []string {
+	return t.fields
+}
+
+// Partials returns the names of the partials the template includes, e.g.
+// "header" for {{ template "header" . }}, not including templates it defines
+func (t *Template) Partials() []string {
+	return t.partials
+}
+
+// keep returns a copy of the data map with the placeholder of each of the keys
+// of the template and its bound partials that the data map is missing
+func (t *Template) keep(data map[string]string) map[string]string {
+	kept := make(map[string]string, len(data)+len(t.fields))
+	for key, value := range data {
+		kept[key] = value
+	}
+	fields := append(append([]string{}, t.fields...), t.partialFields...)
+	for _, field := range fields {
+		if _, ok := kept[field]; !ok {
+			kept[field] = "{{." + field + "}}"
+		}
+	}
+	return kept
+}
+
+// walk returns the nodes of a parse tree
+func walk(node parse.Node, found []parse.Node) []parse.Node {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return found
+		}
+		for _, child := range n.Nodes {
+			found = walk(child, found)
+		}
+	case *parse.ActionNode:
+		found = walk(n.Pipe, found)
+	case *parse.PipeNode:
+		if n == nil {
+			return found
+		}
+		for _, cmd := range n.Cmds {
+			found = walk(cmd, found)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			found = walk(arg, found)
+		}
+	case *parse.FieldNode:
+		found = append(found, n)
+	case *parse.TemplateNode:
+		found = append(found, n)
+		found = walk(n.Pipe, found)
+	case *parse.IfNode:
+		found = walk(n.Pipe, found)
+		found = walk(n.List, found)
+		found = walk(n.ElseList, found)
+	case *parse.RangeNode:
+		found = walk(n.Pipe, found)
+		found = walk(n.List, found)
+		found = walk(n.ElseList, found)
+	case *parse.WithNode:
+		found = walk(n.Pipe, found)
+		found = walk(n.List, found)
+		found = walk(n.ElseList, found)
+	}
+	return found
+}
+
+// newError converts an error from the "text/template" package, which is
+// prefixed with the name of the template and the position of the error, e.g.
+// `template: name:2:4: executing "name" at <.key>: ...`, to an *Error
+func newError(name string, err error) *Error {
+	message := err.Error()
+	prefix := "template: " + name + ":"
+	if !strings.HasPrefix(message, prefix) {
+		return &Error{Name: name, Message: strings.TrimPrefix(message, "template: ")}
+	}
+	message = message[len(prefix):]
+	e := &Error{Name: name}
+	positions := []int{}
+	for len(positions) < 2 {
+		i := strings.Index(message, ":")
+		if i < 0 {
+			break
+		}
+		n, err := strconv.Atoi(message[:i])
+		if err != nil {
+			break
+		}
+		positions = append(positions, n)
+		message = message[i+1:]
+	}
+	if len(positions) > 0 {
+		e.Line = positions[0]
+	}
+	if len(positions) > 1 {
+		// the "text/template" package counts columns from 0
+		e.Column = positions[1] + 1
+	}
+	message = strings.TrimSpace(message)
+	message = strings.TrimPrefix(message, fmt.Sprintf("executing %q at ", name))
+	e.Message = message
+	return e
+}
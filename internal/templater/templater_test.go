@@ -0,0 +1,92 @@
+package templater
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplaterSuite struct {
+	suite.Suite
+}
+
+func (suite *TemplaterSuite) TestExecute() {
+	t, err := Parse("test-id", "{{ .name }} is {{ .status }}", nil)
+	suite.NoError(err)
+	actual, err := t.Execute(map[string]string{"name": "web", "status": "running"}, nil)
+	suite.NoError(err)
+	suite.Equal("web is running", actual)
+}
+
+func (suite *TemplaterSuite) TestExecuteWithMissingKey() {
+	t, err := Parse("test-id", "{{ .name }} is {{ .status }}{{ if .ready }}!{{ end }}", nil)
+	suite.NoError(err)
+	data := map[string]string{"name": "web"}
+	tests := []struct {
+		policy   string
+		expected string
+	}{
+		{"", "web is "},
+		{MissingEmpty, "web is "},
+		{MissingKeep, "web is {{.status}}!"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.policy, func() {
+			actual, err := t.Execute(data, &Options{Missing: tt.policy})
+			suite.NoError(err)
+			suite.Equal(tt.expected, actual)
+		})
+	}
+	_, err = t.Execute(data, &Options{Missing: MissingError})
+	suite.EqualError(err, `template test-id: line 1, column 19: <.status>: map has no entry for key "status"`)
+	suite.Equal(map[string]string{"name": "web"}, data)
+}
+
+func (suite *TemplaterSuite) TestExecuteWithFuncs() {
+	t, err := Parse("test-id", "{{ shout .name }}", template.FuncMap{"shout": strings.ToUpper})
+	suite.NoError(err)
+	actual, err := t.Execute(map[string]string{"name": "web"}, nil)
+	suite.NoError(err)
+	suite.Equal("WEB", actual)
+	exclaim := func(s string) string { return s + "!" }
+	actual, err = t.Execute(map[string]string{"name": "web"}, &Options{Funcs: template.FuncMap{"shout": exclaim}})
+	suite.NoError(err)
+	suite.Equal("web!", actual)
+}
+
+func (suite *TemplaterSuite) TestBindPartials() {
+	t, err := Parse("test-id", `{{ template "header" . }} is {{ .status }}{{ define "local" }}{{ end }}{{ template "local" }}`, nil)
+	suite.NoError(err)
+	suite.Equal([]string{"header"}, t.Partials())
+	header, err := Parse("header", "[{{ .name }}]", nil)
+	suite.NoError(err)
+	data := map[string]string{"status": "running"}
+	bound, err := t.Bind(map[string]*Template{"header": header})
+	suite.NoError(err)
+	actual, err := bound.Execute(data, &Options{Missing: MissingKeep})
+	suite.NoError(err)
+	suite.Equal("[{{.name}}] is running", actual)
+	actual, err = bound.Execute(map[string]string{"name": "db", "status": "stopped"}, nil)
+	suite.NoError(err)
+	suite.Equal("[db] is stopped", actual)
+	_, err = t.Execute(data, nil)
+	suite.EqualError(err, `template test-id: line 1, column 13: <{{template "header" .}}>: template "header" not defined`)
+}
+
+func (suite *TemplaterSuite) TestParseError() {
+	_, err := Parse("test-id", "{{ .name }}\n{{ .status }", nil)
+	suite.EqualError(err, `template test-id: line 2: unexpected "}" in operand`)
+	var templateErr *Error
+	suite.True(errors.As(err, &templateErr))
+	suite.Equal("test-id", templateErr.Name)
+	suite.Equal(2, templateErr.Line)
+	_, err = Parse("test-id", "{{ unknown .name }}", nil)
+	suite.EqualError(err, `template test-id: line 1: function "unknown" not defined`)
+}
+
+func TestTemplaterSuite(t *testing.T) {
+	suite.Run(t, new(TemplaterSuite))
+}
@@ -0,0 +1,53 @@
+package terminal
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// Width returns the width in columns of the terminal attached to the passed
+// io.Writer. If the writer isn't a terminal, the COLUMNS environment variable
+// is used, and failing that a default width of 80.
+func Width(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if width, _, ok := size(f.Fd()); ok {
+			return width
+		}
+	}
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return defaultWidth
+}
+
+// Height returns the height in rows of the terminal attached to the passed
+// io.Writer. If the writer isn't a terminal, the LINES environment variable is
+// used, and failing that a default height of 24.
+func Height(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if _, height, ok := size(f.Fd()); ok && height > 0 {
+			return height
+		}
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	return defaultHeight
+}
+
+// IsTerminal returns true if the passed io.Writer is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
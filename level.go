@@ -0,0 +1,65 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Debug prints the passed text to the ErrWriter prefixed with "Debug: ", but
+// only if verbose output is on and it isn't quiet. If the text contains
+// formatting verbs (e.g. %v), they will be formatted as per the
+// "...interface{}" variadic parameter in the fashion of fmt.Printf()
+func Debug(i interface{}, a ...interface{}) {
+	singleton.Debug(i, a...)
+}
+
+// Debug prints the passed text to the ErrWriter prefixed with "Debug: ", but
+// only if verbose output is on and it isn't quiet. If the text contains
+// formatting verbs (e.g. %v), they will be formatted as per the
+// "...interface{}" variadic parameter in the fashion of fmt.Printf()
+func (p *Printer) Debug(i interface{}, a ...interface{}) {
+	if p.verbose && !p.quiet {
+		p.leveled("Debug:", Magenta, p.text(i, a...))
+	}
+}
+
+// Info prints the passed text to the ErrWriter prefixed with "Info: ", unless
+// it's quiet. If the text contains formatting verbs (e.g. %v), they will be
+// formatted as per the "...interface{}" variadic parameter in the fashion of
+// fmt.Printf()
+func Info(i interface{}, a ...interface{}) {
+	singleton.Info(i, a...)
+}
+
+// Info prints the passed text to the ErrWriter prefixed with "Info: ", unless
+// it's quiet. If the text contains formatting verbs (e.g. %v), they will be
+// formatted as per the "...interface{}" variadic parameter in the fashion of
+// fmt.Printf()
+func (p *Printer) Info(i interface{}, a ...interface{}) {
+	if p.quiet {
+		return
+	}
+	p.leveled("Info:", Blue, p.text(i, a...))
+}
+
+// Warn prints the passed text to the ErrWriter prefixed with "Warning: ". If
+// the text contains formatting verbs (e.g. %v), they will be formatted as per
+// the "...interface{}" variadic parameter in the fashion of fmt.Printf()
+func Warn(i interface{}, a ...interface{}) {
+	singleton.Warn(i, a...)
+}
+
+// Warn prints the passed text to the ErrWriter prefixed with "Warning: ". If
+// the text contains formatting verbs (e.g. %v), they will be formatted as per
+// the "...interface{}" variadic parameter in the fashion of fmt.Printf()
+func (p *Printer) Warn(i interface{}, a ...interface{}) {
+	p.leveled("Warning:", Yellow, p.text(i, a...))
+}
+
+// leveled prints text to the ErrWriter prefixed with the label in the passed
+// color, with any further lines indented beneath the text
+func (p *Printer) leveled(label, color, text string) {
+	text = strings.TrimSuffix(text, "\n")
+	hanging := strings.Repeat(" ", len(label)+1)
+	fmt.Fprintln(p.ErrWriter, p.colorOr(label, color)+" "+indentLines(text, hanging))
+}
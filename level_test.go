@@ -0,0 +1,31 @@
+package printer
+
+import (
+	"bytes"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *PrinterSuite) leveledOutput(text string, print func(i interface{}, a ...interface{})) string {
+	out := new(bytes.Buffer)
+	singleton.ErrWriter = out
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	suite.Formatter.On("Text", text, mock.Anything).Return(text + "\n")
+	print(text)
+	return out.String()
+}
+
+func (suite *PrinterSuite) TestInfo() {
+	suite.Equal("Info: deployed\n", suite.leveledOutput("deployed", Info))
+}
+
+func (suite *PrinterSuite) TestWarn() {
+	expected := "Warning: disk almost full\n         90% used\n"
+	suite.Equal(expected, suite.leveledOutput("disk almost full\n90% used", Warn))
+}
+
+func (suite *PrinterSuite) TestDebug() {
+	suite.Equal("", suite.leveledOutput("cache miss", Debug))
+	SetVerbose(true)
+	suite.Equal("Debug: cache miss\n", suite.leveledOutput("cache miss", Debug))
+}
@@ -0,0 +1,136 @@
+package printer
+
+import (
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+// List styles
+const (
+	Bullet   = formatter.Bullet
+	Numbered = formatter.Numbered
+	Checkbox = formatter.Checkbox
+	Lettered = formatter.Lettered
+)
+
+// ListItem is a single item of a list. Children are rendered as a nested list
+// beneath the item. Checked is only used by the Checkbox list style.
+type ListItem struct {
+	Text     string
+	Checked  bool
+	Children []*ListItem
+}
+
+// ListOptions are the options used to render a list. Style is one of Bullet
+// (the default), Numbered, Checkbox or Lettered. Color is the color of the list
+// glyphs. Indent is the number of spaces each level of nesting is indented by
+// and defaults to 2. Width is the width items are wrapped at and defaults to
+// the width of the Printer.
+type ListOptions struct {
+	Style  string
+	Color  string
+	Indent int
+	Width  int
+}
+
+// ListStencil is a stencil applied to each item of a list
+type ListStencil struct {
+	ID        string
+	Template  string
+	Colors    map[string]string
+	Formats   map[string]*ValueFormat
+	Localized map[string]string
+}
+
+// List prints the passed items as a list. Nested items are indented, and items
+// longer than the width are wrapped with a hanging indent. If opts is nil the
+// default ListOptions are used.
+func List(items []*ListItem, opts *ListOptions) {
+	singleton.List(items, opts)
+}
+
+// List prints the passed items as a list. Nested items are indented, and items
+// longer than the width are wrapped with a hanging indent. If opts is nil the
+// default ListOptions are used.
+func (p *Printer) List(items []*ListItem, opts *ListOptions) {
+	p.printLines(p.formatter.List(toFormatterListItems(items), p.formatterListOptions(opts)))
+}
+
+// UseListStencil takes the ID of a List Stencil and a slice of "item" maps with
+// string key/values. It returns an error if it can't find a Stencil with the
+// passed ID. It applies the List Stencil's template to each item map and prints
+// the results as a list as per the passed ListOptions.
+func UseListStencil(id string, items []map[string]string, opts *ListOptions) error {
+	return singleton.UseListStencil(id, items, opts)
+}
+
+// UseListStencil takes the ID of a List Stencil and a slice of "item" maps with
+// string key/values. It returns an error if it can't find a Stencil with the
+// passed ID. It applies the List Stencil's template to each item map and prints
+// the results as a list as per the passed ListOptions.
+func (p *Printer) UseListStencil(id string, items []map[string]string, opts *ListOptions) error {
+	results, err := p.stenciller.UseListStencil(id, items)
+	if err != nil {
+		return err
+	}
+	listItems := make([]*ListItem, len(results))
+	for i, result := range results {
+		listItems[i] = &ListItem{Text: result}
+	}
+	p.List(listItems, opts)
+	return nil
+}
+
+// AddListStencil adds a new List Stencil with the passed ID, template and
+// colors. It returns an error if the template can't be parsed, as per
+// AddTemplateStencil.
+func AddListStencil(stencil *ListStencil) error {
+	return singleton.AddListStencil(stencil)
+}
+
+// AddListStencil adds a new List Stencil with the passed ID, template and
+// colors. It returns an error if the template can't be parsed, as per
+// AddTemplateStencil.
+func (p *Printer) AddListStencil(stencil *ListStencil) error {
+	return p.stenciller.AddListStencil(toStencillerList(stencil))
+}
+
+func (p *Printer) formatterListOptions(opts *ListOptions) *formatter.ListOptions {
+	fOpts := &formatter.ListOptions{Style: Bullet, Indent: 2, Width: p.Width()}
+	if opts == nil {
+		return fOpts
+	}
+	if opts.Style != "" {
+		fOpts.Style = opts.Style
+	}
+	if opts.Indent > 0 {
+		fOpts.Indent = opts.Indent
+	}
+	if opts.Width > 0 {
+		fOpts.Width = opts.Width
+	}
+	fOpts.Color = opts.Color
+	return fOpts
+}
+
+func toFormatterListItems(items []*ListItem) []*formatter.ListItem {
+	fItems := make([]*formatter.ListItem, len(items))
+	for i, item := range items {
+		fItems[i] = &formatter.ListItem{
+			Text:     item.Text,
+			Checked:  item.Checked,
+			Children: toFormatterListItems(item.Children),
+		}
+	}
+	return fItems
+}
+
+func toStencillerList(stencil *ListStencil) *stenciller.ListStencil {
+	return &stenciller.ListStencil{
+		ID:        stencil.ID,
+		Template:  stencil.Template,
+		Colors:    stencil.Colors,
+		Formats:   humanizeFormats(stencil.Formats),
+		Localized: stencil.Localized,
+	}
+}
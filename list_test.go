@@ -0,0 +1,58 @@
+package printer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+func (suite *PrinterSuite) TestList() {
+	items := []*ListItem{
+		{Text: "first", Children: []*ListItem{{Text: "nested"}}},
+		{Text: "second"},
+	}
+	expected := []*formatter.ListItem{
+		{Text: "first", Children: []*formatter.ListItem{{Text: "nested", Children: []*formatter.ListItem{}}}},
+		{Text: "second", Children: []*formatter.ListItem{}},
+	}
+	opts := &formatter.ListOptions{Style: Numbered, Color: Red, Indent: 2, Width: 80}
+	suite.Formatter.On("List", expected, opts).Return([]string{"line1", "line2"})
+	List(items, &ListOptions{Style: Numbered, Color: Red})
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("line1"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("line2"))
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 2)
+}
+
+func (suite *PrinterSuite) TestListWithDefaultOptions() {
+	opts := &formatter.ListOptions{Style: Bullet, Indent: 2, Width: 80}
+	suite.Formatter.On("List", mock.Anything, opts).Return([]string{"line"})
+	List([]*ListItem{{Text: "item"}}, nil)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("line"))
+}
+
+func (suite *PrinterSuite) TestListStencil() {
+	id := "test id"
+	items := []map[string]string{{"key": "value1"}, {"key": "value2"}}
+	expected := []*formatter.ListItem{
+		{Text: "stencilled1", Children: []*formatter.ListItem{}},
+		{Text: "stencilled2", Children: []*formatter.ListItem{}},
+	}
+	suite.Stenciller.On("UseListStencil", id, items).Return([]string{"stencilled1", "stencilled2"}, nil)
+	suite.Formatter.On("List", expected, mock.Anything).Return([]string{"line1", "line2"})
+	err := UseListStencil(id, items, nil)
+	suite.NoError(err)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("line1"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("line2"))
+}
+
+func (suite *PrinterSuite) TestListStencilWithError() {
+	id := "test id"
+	items := []map[string]string{{"key": "value"}}
+	suite.Stenciller.On("UseListStencil", id, items).Return([]string{}, errors.New("error"))
+	err := UseListStencil(id, items, nil)
+	suite.Error(err)
+	suite.Formatter.AssertNotCalled(suite.T(), "List", mock.Anything, mock.Anything)
+	suite.OutWriter.AssertNotCalled(suite.T(), "Write", mock.Anything)
+}
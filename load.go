@@ -0,0 +1,122 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+// stencilFile is a JSON file of Stencils, with fields matched to those of the
+// Stencils case-insensitively, e.g.
+//
+//	{
+//	  "partials": {"name": "[{{ .name }}]"},
+//	  "templates": [{"id": "status", "template": "{{ template \"name\" . }} {{ .status }}"}],
+//	  "tables": [{"id": "pods", "columnOrder": ["name", "status"], "headers": ["Name", "Status"]}],
+//	  "lists": [{"id": "pod", "template": "{{ .name }}"}]
+//	}
+type stencilFile struct {
+	Partials  map[string]string
+	Templates []*TemplateStencil
+	Tables    []*TableStencil
+	Lists     []*ListStencil
+}
+
+// LoadStencilDir loads the Stencils of every JSON file in the passed directory
+// as per LoadStencilFile. It returns an error if the directory can't be read,
+// or the errors of the files that can't be loaded joined by errors.Join.
+func LoadStencilDir(dir string) error {
+	return singleton.LoadStencilDir(dir)
+}
+
+// LoadStencilDir loads the Stencils of every JSON file in the passed directory
+// as per LoadStencilFile. It returns an error if the directory can't be read,
+// or the errors of the files that can't be loaded joined by errors.Join.
+func (p *Printer) LoadStencilDir(dir string) error {
+	paths, err := stencilFiles(dir)
+	if err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, path := range paths {
+		if err := p.LoadStencilFile(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadStencilFile loads the partials and the Template, Table and List Stencils
+// of a JSON file, with "partials", "templates", "tables" and "lists" fields,
+// replacing any loaded from the file before. The file is validated as a whole
+// before any of it is loaded, so if it can't be read or decoded, or any of its
+// Stencils is invalid, it returns an error and the Stencils last loaded from
+// the file are kept. Table Stencils of the file may extend each other in any
+// order.
+func LoadStencilFile(path string) error {
+	return singleton.LoadStencilFile(path)
+}
+
+// LoadStencilFile loads the partials and the Template, Table and List Stencils
+// of a JSON file, with "partials", "templates", "tables" and "lists" fields,
+// replacing any loaded from the file before. The file is validated as a whole
+// before any of it is loaded, so if it can't be read or decoded, or any of its
+// Stencils is invalid, it returns an error and the Stencils last loaded from
+// the file are kept. Table Stencils of the file may extend each other in any
+// order.
+func (p *Printer) LoadStencilFile(path string) error {
+	set, err := readStencilFile(path)
+	if err == nil {
+		err = p.stenciller.Load(path, set)
+	}
+	if err != nil {
+		return fmt.Errorf("Unable to load stencils from %v: %w", path, err)
+	}
+	return nil
+}
+
+func readStencilFile(path string) (*stenciller.Set, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	file := &stencilFile{}
+	if err := decoder.Decode(file); err != nil {
+		return nil, err
+	}
+	set := &stenciller.Set{Partials: file.Partials}
+	for _, stencil := range file.Templates {
+		set.Templates = append(set.Templates, toStencillerTemplate(stencil))
+	}
+	for _, stencil := range file.Tables {
+		set.Tables = append(set.Tables, toStencillerTable(stencil))
+	}
+	for _, stencil := range file.Lists {
+		set.Lists = append(set.Lists, toStencillerList(stencil))
+	}
+	return set, nil
+}
+
+// stencilFiles returns the paths of the JSON files in a directory in order
+func stencilFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read stencil directory %v: %w", dir, err)
+	}
+	paths := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
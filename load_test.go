@@ -0,0 +1,60 @@
+package printer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) writeStencilFile(dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	suite.Require().NoError(os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func (suite *PrinterSuite) TestLoadStencilFile() {
+	path := suite.writeStencilFile(suite.T().TempDir(), "pods.json", `{
+		"partials": {"name": "[{{ .name }}]"},
+		"templates": [{"id": "status", "template": "{{ template \"name\" . }} {{ .status }}"}],
+		"tables": [{"id": "pods", "columnOrder": ["name", "size"], "formats": {"size": {"type": "bytes"}}}],
+		"lists": [{"id": "pod", "template": "{{ .name }}"}]
+	}`)
+	suite.Stenciller.On("Load", path, mock.MatchedBy(func(set *stenciller.Set) bool {
+		return set.Partials["name"] == "[{{ .name }}]" &&
+			set.Templates[0].ID == "status" &&
+			set.Tables[0].ColumnOrder[1] == "size" &&
+			set.Tables[0].Formats["size"].Type == BytesFormat &&
+			set.Lists[0].Template == "{{ .name }}"
+	})).Return(nil)
+	suite.NoError(LoadStencilFile(path))
+}
+
+func (suite *PrinterSuite) TestLoadStencilFileWithErrors() {
+	dir := suite.T().TempDir()
+	path := suite.writeStencilFile(dir, "typo.json", `{"tables": [{"id": "pods", "colunmOrder": ["name"]}]}`)
+	err := LoadStencilFile(path)
+	suite.EqualError(err, "Unable to load stencils from "+path+`: json: unknown field "colunmOrder"`)
+	path = suite.writeStencilFile(dir, "invalid.json", `{"templates": [{"id": "status"}]}`)
+	validationErr := &ValidationError{Kind: "template", ID: "status"}
+	suite.Stenciller.On("Load", path, mock.Anything).Return(validationErr)
+	err = LoadStencilFile(path)
+	suite.True(errors.Is(err, validationErr))
+	suite.Stenciller.AssertNumberOfCalls(suite.T(), "Load", 1)
+}
+
+func (suite *PrinterSuite) TestLoadStencilDir() {
+	dir := suite.T().TempDir()
+	first := suite.writeStencilFile(dir, "a.json", `{}`)
+	second := suite.writeStencilFile(dir, "b.json", `{`)
+	suite.writeStencilFile(dir, "notes.txt", `{`)
+	suite.Stenciller.On("Load", first, mock.Anything).Return(nil)
+	err := LoadStencilDir(dir)
+	suite.EqualError(err, "Unable to load stencils from "+second+": unexpected EOF")
+	suite.Stenciller.AssertNumberOfCalls(suite.T(), "Load", 1)
+	err = LoadStencilDir(filepath.Join(dir, "missing"))
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "Unable to read stencil directory")
+}
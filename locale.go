@@ -0,0 +1,136 @@
+package printer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tomguerney/printer/internal/locale"
+)
+
+// Plural categories
+const (
+	PluralOne   = locale.One
+	PluralFew   = locale.Few
+	PluralMany  = locale.Many
+	PluralOther = locale.Other
+)
+
+// SetLocale sets the locale to format values for and localize Stencils for,
+// such as "de", "pt-BR" or "en_GB.UTF-8". It returns an error if there's no
+// locale data for the locale's language.
+//
+// The locale sets the decimal and group separators of numbers, the layout of
+// times and the plural rules used by the value formatters and helpers. Template
+// Stencils and List Stencils use their Localized variant for the locale and
+// Table Stencils their LocalizedHeaders, if they have one. Otherwise their
+// templates, headers and footer labels are translated by the locale's message
+// catalog.
+func SetLocale(tag string) error {
+	return singleton.SetLocale(tag)
+}
+
+// SetLocale sets the locale to format values for and localize Stencils for,
+// such as "de", "pt-BR" or "en_GB.UTF-8". It returns an error if there's no
+// locale data for the locale's language.
+//
+// The locale sets the decimal and group separators of numbers, the layout of
+// times and the plural rules used by the value formatters and helpers. Template
+// Stencils and List Stencils use their Localized variant for the locale and
+// Table Stencils their LocalizedHeaders, if they have one. Otherwise their
+// templates, headers and footer labels are translated by the locale's message
+// catalog.
+func (p *Printer) SetLocale(tag string) error {
+	l, err := locale.Lookup(tag)
+	if err != nil {
+		return fmt.Errorf("Unable to set locale: %v", err)
+	}
+	p.locale = l
+	p.stenciller.SetLocale(l)
+	return nil
+}
+
+// Locale returns the tag of the locale, which is "en" unless it has been set
+func Locale() string {
+	return singleton.Locale()
+}
+
+// Locale returns the tag of the locale, which is "en" unless it has been set
+func (p *Printer) Locale() string {
+	return p.loc().Tag
+}
+
+// AddCatalog adds a message catalog for the locale with the passed tag. The
+// catalog maps text, such as the templates and headers of Stencils, to its
+// translation in the locale. A catalog for a language, such as "de", is used
+// for any locale of that language without a catalog of its own.
+func AddCatalog(tag string, messages map[string]string) {
+	singleton.AddCatalog(tag, messages)
+}
+
+// AddCatalog adds a message catalog for the locale with the passed tag. The
+// catalog maps text, such as the templates and headers of Stencils, to its
+// translation in the locale. A catalog for a language, such as "de", is used
+// for any locale of that language without a catalog of its own.
+func (p *Printer) AddCatalog(tag string, messages map[string]string) {
+	p.stenciller.AddCatalog(tag, messages)
+}
+
+// Translate returns the translation of the passed text in the message catalog
+// of the locale, or the text itself if it has no translation
+func Translate(text string) string {
+	return singleton.Translate(text)
+}
+
+// Translate returns the translation of the passed text in the message catalog
+// of the locale, or the text itself if it has no translation
+func (p *Printer) Translate(text string) string {
+	return p.stenciller.Message(text)
+}
+
+// Plural returns the form of the passed plural forms for n in the locale's
+// plural rules, with any "#" replaced by n. The forms are keyed by plural
+// category, which is one of PluralOne, PluralFew, PluralMany and PluralOther,
+// with PluralOther used if there's no form for n's category. Each form is
+// translated by the locale's message catalog. For example, in English
+// Plural(3, map[string]string{PluralOne: "# file", PluralOther: "# files"})
+// returns "3 files".
+func Plural(n int, forms map[string]string) string {
+	return singleton.Plural(n, forms)
+}
+
+// Plural returns the form of the passed plural forms for n in the locale's
+// plural rules, with any "#" replaced by n. The forms are keyed by plural
+// category, which is one of PluralOne, PluralFew, PluralMany and PluralOther,
+// with PluralOther used if there's no form for n's category. Each form is
+// translated by the locale's message catalog. For example, in English
+// Plural(3, map[string]string{PluralOne: "# file", PluralOther: "# files"})
+// returns "3 files".
+func (p *Printer) Plural(n int, forms map[string]string) string {
+	form, ok := forms[p.loc().Plural(float64(n))]
+	if !ok {
+		form = forms[PluralOther]
+	}
+	number := p.loc().Number(strconv.Itoa(n), "")
+	return strings.Replace(p.Translate(form), "#", number, -1)
+}
+
+// FormatNumber formats a number with the passed number of decimal places, and
+// the decimal and group separators of the locale, e.g. "1,234.5"
+func FormatNumber(n float64, precision int) string {
+	return singleton.FormatNumber(n, precision)
+}
+
+// FormatNumber formats a number with the passed number of decimal places, and
+// the decimal and group separators of the locale, e.g. "1,234.5"
+func (p *Printer) FormatNumber(n float64, precision int) string {
+	return p.loc().Number(strconv.FormatFloat(n, 'f', precision, 64), "")
+}
+
+// loc returns the locale, or the default locale if it hasn't been set
+func (p *Printer) loc() *locale.Locale {
+	if p.locale == nil {
+		return locale.Default
+	}
+	return p.locale
+}
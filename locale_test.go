@@ -0,0 +1,72 @@
+package printer
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/locale"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestSetLocale() {
+	suite.Stenciller.On("SetLocale", mock.MatchedBy(func(l *locale.Locale) bool {
+		return l.Tag == "de-CH"
+	})).Return()
+	suite.Equal("en", Locale())
+	err := SetLocale("de_CH.UTF-8")
+	suite.NoError(err)
+	suite.Equal("de-CH", Locale())
+}
+
+func (suite *PrinterSuite) TestSetUnknownLocale() {
+	err := SetLocale("xx")
+	suite.EqualError(err, "Unable to set locale: unknown locale xx")
+	suite.Stenciller.AssertNotCalled(suite.T(), "SetLocale", mock.Anything)
+}
+
+func (suite *PrinterSuite) TestLocalizedHelpers() {
+	suite.Stenciller.On("SetLocale", mock.Anything).Return()
+	suite.NoError(SetLocale("de"))
+	suite.Equal("1.234.567,89", FormatNumber(1234567.891, 2))
+	suite.Equal("1.234", FormatThousands(1234))
+	suite.Equal("45,7%", FormatPercent(45.678, 1))
+	suite.Equal("1,5 KiB", FormatBytes(1536, false))
+	suite.Equal("09.03.2021 08:15", FormatTime(time.Date(2021, 3, 9, 8, 15, 0, 0, time.UTC), "", time.UTC))
+}
+
+func (suite *PrinterSuite) TestPlural() {
+	suite.Stenciller.On("SetLocale", mock.Anything).Return()
+	forms := map[string]string{PluralOne: "# file", PluralOther: "# files"}
+	suite.Stenciller.On("Message", "# file").Return("# file")
+	suite.Stenciller.On("Message", "# files").Return("# files")
+	suite.Equal("1 file", Plural(1, forms))
+	suite.Equal("1,000 files", Plural(1000, forms))
+	suite.NoError(SetLocale("ru"))
+	forms = map[string]string{PluralOne: "# файл", PluralFew: "# файла", PluralMany: "# файлов"}
+	for _, form := range forms {
+		suite.Stenciller.On("Message", form).Return(form)
+	}
+	suite.Equal("21 файл", Plural(21, forms))
+	suite.Equal("3 файла", Plural(3, forms))
+	suite.Equal("5 файлов", Plural(5, forms))
+}
+
+func (suite *PrinterSuite) TestAddCatalog() {
+	messages := map[string]string{"Name": "Nom"}
+	suite.Stenciller.On("AddCatalog", "fr", messages).Return()
+	suite.Stenciller.On("Message", "Name").Return("Nom")
+	AddCatalog("fr", messages)
+	suite.Equal("Nom", Translate("Name"))
+}
+
+func (suite *PrinterSuite) TestAddTableStencilWithLocalizedHeaders() {
+	stencil := &TableStencil{
+		ID:               "test id",
+		Headers:          []string{"Name"},
+		LocalizedHeaders: map[string][]string{"fr": {"Nom"}},
+	}
+	suite.Stenciller.On("AddTableStencil", mock.MatchedBy(func(s *stenciller.TableStencil) bool {
+		return s.LocalizedHeaders["fr"][0] == "Nom"
+	})).Return(nil)
+	suite.NoError(AddTableStencil(stencil))
+}
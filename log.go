@@ -0,0 +1,236 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultLogTheme is the color of the level label of each zerolog level
+var DefaultLogTheme = map[string]string{
+	zerolog.TraceLevel.String(): Magenta,
+	zerolog.DebugLevel.String(): Blue,
+	zerolog.InfoLevel.String():  Green,
+	zerolog.WarnLevel.String():  Yellow,
+	zerolog.ErrorLevel.String(): Red,
+	zerolog.FatalLevel.String(): Red,
+	zerolog.PanicLevel.String(): Red,
+}
+
+var levelLabels = map[string]string{
+	zerolog.TraceLevel.String(): "TRC",
+	zerolog.DebugLevel.String(): "DBG",
+	zerolog.InfoLevel.String():  "INF",
+	zerolog.WarnLevel.String():  "WRN",
+	zerolog.ErrorLevel.String(): "ERR",
+	zerolog.FatalLevel.String(): "FTL",
+	zerolog.PanicLevel.String(): "PNC",
+}
+
+// LogOptions are the options used to render zerolog events. Theme is the color
+// of the level label of each level and defaults to DefaultLogTheme. Timestamps
+// are formatted with TimeLayout in Location, which default to "15:04:05" and
+// the local time zone, or relative to now (e.g. "3 minutes ago") if
+// RelativeTime is true.
+//
+// If StencilID is set, each event is rendered with the Template Stencil with
+// that ID instead of the default layout. The data map of the Stencil has the
+// formatted "time", the colored "level" label, the "message" and every other
+// field of the event.
+type LogOptions struct {
+	Theme        map[string]string
+	TimeLayout   string
+	Location     *time.Location
+	RelativeTime bool
+	StencilID    string
+}
+
+// LogWriter is an io.Writer that renders zerolog JSON events and prints them to
+// the ErrWriter of a Printer. It can be used in place of a
+// zerolog.ConsoleWriter, e.g. zerolog.New(printer.NewLogWriter(nil)).
+type LogWriter struct {
+	printer *Printer
+	opts    *LogOptions
+}
+
+// NewLogWriter returns a LogWriter that renders zerolog events as per the passed
+// LogOptions. Each event is printed as its timestamp, level and message,
+// followed by its other fields aligned as key/value pairs. If opts is nil the
+// default LogOptions are used.
+func NewLogWriter(opts *LogOptions) *LogWriter {
+	return singleton.NewLogWriter(opts)
+}
+
+// NewLogWriter returns a LogWriter that renders zerolog events as per the passed
+// LogOptions. Each event is printed as its timestamp, level and message,
+// followed by its other fields aligned as key/value pairs. If opts is nil the
+// default LogOptions are used.
+func (p *Printer) NewLogWriter(opts *LogOptions) *LogWriter {
+	if opts == nil {
+		opts = &LogOptions{}
+	}
+	return &LogWriter{printer: p, opts: opts}
+}
+
+// Write renders each newline-delimited zerolog JSON event of the passed bytes
+// and prints it to the ErrWriter of the Printer. It returns an error if an
+// event isn't a JSON object or can't be rendered with the Template Stencil.
+func (w *LogWriter) Write(b []byte) (int, error) {
+	for _, event := range bytes.Split(b, []byte("\n")) {
+		if len(bytes.TrimSpace(event)) == 0 {
+			continue
+		}
+		lines, err := w.render(event)
+		if err != nil {
+			return 0, err
+		}
+		for _, line := range lines {
+			fmt.Fprintln(w.printer.ErrWriter, line)
+		}
+	}
+	return len(b), nil
+}
+
+func (w *LogWriter) render(event []byte) ([]string, error) {
+	fields := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(event))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("Unable to parse log event: %v", err)
+	}
+	data := make(map[string]string, len(fields))
+	for key, value := range fields {
+		data[key] = logValue(value)
+	}
+	timestamp := w.timestamp(fields[zerolog.TimestampFieldName])
+	level, message := w.level(fields), data[zerolog.MessageFieldName]
+	delete(data, zerolog.TimestampFieldName)
+	delete(data, zerolog.LevelFieldName)
+	delete(data, zerolog.MessageFieldName)
+	if w.opts.StencilID != "" {
+		data["time"], data["level"], data["message"] = timestamp, level, message
+		result, err := w.printer.stenciller.UseTemplateStencil(w.opts.StencilID, data)
+		if err != nil {
+			return nil, err
+		}
+		return strings.Split(result, "\n"), nil
+	}
+	header := make([]string, 0, 3)
+	for _, part := range []string{w.printer.colorOr(timestamp, "dim"), level, message} {
+		if part != "" {
+			header = append(header, part)
+		}
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return append([]string{strings.Join(header, " ")}, w.printer.logFields(keys, data)...), nil
+}
+
+// timestamp formats the timestamp field of an event, which is either a string
+// in the zerolog.TimeFieldFormat or a Unix time
+func (w *LogWriter) timestamp(value interface{}) string {
+	var t time.Time
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case json.Number:
+		n, err := value.Int64()
+		if err != nil {
+			return value.String()
+		}
+		switch zerolog.TimeFieldFormat {
+		case zerolog.TimeFormatUnixMs:
+			t = time.Unix(0, n*int64(time.Millisecond))
+		case zerolog.TimeFormatUnixMicro:
+			t = time.Unix(0, n*int64(time.Microsecond))
+		default:
+			t = time.Unix(n, 0)
+		}
+	case string:
+		parsed, err := time.Parse(zerolog.TimeFieldFormat, value)
+		if err != nil {
+			if parsed, err = time.Parse(time.RFC3339Nano, value); err != nil {
+				return value
+			}
+		}
+		t = parsed
+	default:
+		return logValue(value)
+	}
+	if w.opts.RelativeTime {
+		return w.printer.FormatRelativeTime(t)
+	}
+	layout := w.opts.TimeLayout
+	if layout == "" {
+		layout = "15:04:05"
+	}
+	return w.printer.FormatTime(t, layout, w.opts.Location)
+}
+
+// level returns the label of the level of an event in the color of the theme
+func (w *LogWriter) level(fields map[string]interface{}) string {
+	level, ok := fields[zerolog.LevelFieldName].(string)
+	if !ok {
+		return ""
+	}
+	label, ok := levelLabels[level]
+	if !ok {
+		label = strings.ToUpper(level)
+	}
+	theme := w.opts.Theme
+	if theme == nil {
+		theme = DefaultLogTheme
+	}
+	return w.printer.colorOr(label, theme[level])
+}
+
+// logFields returns the passed fields as lines of key/value pairs in the order
+// of the passed keys, with the values aligned and errors in red
+func (p *Printer) logFields(keys []string, fields map[string]string) []string {
+	width := 0
+	for _, key := range keys {
+		if len(key) > width {
+			width = len(key)
+		}
+	}
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		value := indentLines(fields[key], strings.Repeat(" ", width+4))
+		if key == zerolog.ErrorFieldName {
+			value = p.colorOr(value, Red)
+		}
+		padding := strings.Repeat(" ", width-len(key))
+		lines[i] = fmt.Sprintf("  %v%v %v", p.colorOr(key+":", Cyan), padding, value)
+	}
+	return lines
+}
+
+// logValue returns a field value of an event as a string, with objects and
+// arrays as compact JSON
+func logValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,80 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *PrinterSuite) logOutput(opts *LogOptions, log func(zerolog.Logger)) string {
+	out := new(bytes.Buffer)
+	singleton.ErrWriter = out
+	log(zerolog.New(NewLogWriter(opts)))
+	return out.String()
+}
+
+func (suite *PrinterSuite) TestLogWriter() {
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	timestamp := time.Date(2021, 8, 1, 14, 30, 5, 0, time.UTC)
+	output := suite.logOutput(&LogOptions{Location: time.UTC}, func(logger zerolog.Logger) {
+		logger.Info().
+			Time(zerolog.TimestampFieldName, timestamp).
+			Str("service", "web").
+			Int("port", 8080).
+			Strs("tags", []string{"a", "b"}).
+			Err(errors.New("connection refused")).
+			Msg("server started")
+	})
+	expected := strings.Join([]string{
+		"14:30:05 INF server started",
+		"  error:   connection refused",
+		"  port:    8080",
+		"  service: web",
+		`  tags:    ["a","b"]`,
+		"",
+	}, "\n")
+	suite.Equal(expected, output)
+}
+
+func (suite *PrinterSuite) TestLogWriterWithTheme() {
+	suite.Stenciller.On("Color", "WRN", Magenta).Return("magenta WRN", true)
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	output := suite.logOutput(&LogOptions{Theme: map[string]string{"warn": Magenta}}, func(logger zerolog.Logger) {
+		logger.Warn().Msg("disk almost full")
+	})
+	suite.Equal("magenta WRN disk almost full\n", output)
+}
+
+func (suite *PrinterSuite) TestLogWriterWithMultiLineField() {
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	output := suite.logOutput(nil, func(logger zerolog.Logger) {
+		logger.Debug().Str("stack", "main.main\nmain.go:10").Str("id", "42").Send()
+	})
+	expected := strings.Join([]string{
+		"DBG",
+		"  id:    42",
+		"  stack: main.main",
+		"         main.go:10",
+		"",
+	}, "\n")
+	suite.Equal(expected, output)
+}
+
+func (suite *PrinterSuite) TestLogWriterWithStencil() {
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	expected := map[string]string{"time": "", "level": "ERR", "message": "failed", "user": "tom"}
+	suite.Stenciller.On("UseTemplateStencil", "log", expected).Return("ERR failed (tom)", nil)
+	output := suite.logOutput(&LogOptions{StencilID: "log"}, func(logger zerolog.Logger) {
+		logger.Error().Str("user", "tom").Msg("failed")
+	})
+	suite.Equal("ERR failed (tom)\n", output)
+}
+
+func (suite *PrinterSuite) TestLogWriterWithInvalidEvent() {
+	_, err := NewLogWriter(nil).Write([]byte("not json\n"))
+	suite.EqualError(err, "Unable to parse log event: invalid character 'o' in literal null (expecting 'u')")
+}
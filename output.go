@@ -0,0 +1,101 @@
+package printer
+
+import (
+	"os"
+
+	"github.com/tomguerney/printer/internal/colorer"
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/terminal"
+)
+
+// TableOutput is the default output format, printing tables as text
+const TableOutput = "table"
+
+// Color modes
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// Table styles
+const (
+	TablePlain   = "plain"
+	TableCompact = "compact"
+	TableStriped = "striped"
+)
+
+// SetOutputFormat sets the format tables are printed in by UseTableStencil.
+// With TableOutput (the default) they are tabulated as text, and with CSV, TSV,
+// Markdown or HTML they are exported to the OutWriter as per ExportTable.
+func SetOutputFormat(format string) {
+	singleton.SetOutputFormat(format)
+}
+
+// SetOutputFormat sets the format tables are printed in by UseTableStencil.
+// With TableOutput (the default) they are tabulated as text, and with CSV, TSV,
+// Markdown or HTML they are exported to the OutWriter as per ExportTable.
+func (p *Printer) SetOutputFormat(format string) {
+	p.outputFormat = format
+}
+
+// SetColorMode sets when output is colored. With ColorAuto (the default),
+// output is colored if the OutWriter is a terminal, unless the NO_COLOR
+// environment variable is set or TERM is "dumb". With ColorAlways it always is,
+// and with ColorNever it never is. The color mode applies to every Printer.
+func SetColorMode(mode string) {
+	singleton.SetColorMode(mode)
+}
+
+// SetColorMode sets when output is colored. With ColorAuto (the default),
+// output is colored if the OutWriter is a terminal, unless the NO_COLOR
+// environment variable is set or TERM is "dumb". With ColorAlways it always is,
+// and with ColorNever it never is. The color mode applies to every Printer.
+func (p *Printer) SetColorMode(mode string) {
+	switch mode {
+	case ColorAlways:
+		colorer.SetEnabled(true)
+	case ColorNever:
+		colorer.SetEnabled(false)
+	default:
+		colorer.SetEnabled(terminal.IsTerminal(p.OutWriter) &&
+			os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb")
+	}
+}
+
+// SetQuiet sets whether informational output is suppressed. When quiet, Info
+// and Debug print nothing, and the SlogHandler only prints warnings and errors.
+func SetQuiet(quiet bool) {
+	singleton.SetQuiet(quiet)
+}
+
+// SetQuiet sets whether informational output is suppressed. When quiet, Info
+// and Debug print nothing, and the SlogHandler only prints warnings and errors.
+func (p *Printer) SetQuiet(quiet bool) {
+	p.quiet = quiet
+}
+
+// SetTableStyle sets the style tables are printed in. TablePlain (the default)
+// spaces columns 4 apart, TableCompact spaces them 2 apart, and TableStriped
+// dims every other row unless a Stripe is set in the TableOptions. Other
+// tabwriter options are kept.
+func SetTableStyle(style string) {
+	singleton.SetTableStyle(style)
+}
+
+// SetTableStyle sets the style tables are printed in. TablePlain (the default)
+// spaces columns 4 apart, TableCompact spaces them 2 apart, and TableStriped
+// dims every other row unless a Stripe is set in the TableOptions. Other
+// tabwriter options are kept.
+func (p *Printer) SetTableStyle(style string) {
+	p.tableStyle = style
+	options := formatter.TabwriterOptions{Tabwidth: 8, Padchar: ' ', Divchar: '-'}
+	if current := p.formatter.TabwriterOptions(); current != nil {
+		options = *current
+	}
+	options.Padding = 4
+	if style == TableCompact {
+		options.Padding = 2
+	}
+	p.formatter.SetTabwriterOptions(&options)
+}
@@ -0,0 +1,48 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestTableStencilWithOutputFormat() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	rows := []map[string]string{{"name": "web"}, {"name": "db"}}
+	suite.Stenciller.On("TableData", "test id", rows[1:]).Return(&stenciller.Table{
+		Headers: []string{"Name"},
+		Columns: []string{"name"},
+		Rows:    [][]string{{"db"}},
+	}, nil)
+	SetOutputFormat(CSV)
+	err := UseTableStencil("test id", rows, &TableOptions{Where: "name == db"})
+	suite.NoError(err)
+	suite.Equal("Name\r\ndb\r\n", out.String())
+	suite.Stenciller.AssertNotCalled(suite.T(), "UseTableStencil", mock.Anything, mock.Anything)
+}
+
+func (suite *PrinterSuite) TestTableStencilWithStripedStyle() {
+	rows := []map[string]string{{"name": "web"}, {"name": "db"}}
+	stencilled := [][]string{{"web"}, {"db"}}
+	suite.Formatter.On("SetTabwriterOptions", mock.Anything).Return()
+	suite.Formatter.On("TabwriterOptions").Return(&formatter.TabwriterOptions{})
+	suite.Stenciller.On("StencilTable", "test id", rows).Return(&stenciller.StencilledTable{Rows: stencilled}, nil)
+	suite.Formatter.On("Tabulate", stencilled, mock.Anything).Return([]string{"web", "db"})
+	suite.Formatter.On("Style", "db", "dim").Return("dim db")
+	SetTableStyle(TableStriped)
+	suite.NoError(UseTableStencil("test id", rows))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("web"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("dim db"))
+}
+
+func (suite *PrinterSuite) TestQuiet() {
+	SetQuiet(true)
+	SetVerbose(true)
+	Info("deployed")
+	Debug("cache miss")
+	suite.ErrWriter.AssertNotCalled(suite.T(), "Write", mock.Anything)
+}
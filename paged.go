@@ -0,0 +1,182 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/tomguerney/printer/internal/terminal"
+)
+
+// Page navigation options
+const (
+	nextPage     = "Next page"
+	previousPage = "Previous page"
+	firstPage    = "First page"
+	lastPage     = "Last page"
+	quitPaging   = "Quit"
+)
+
+// PagedTable takes the ID of a Table Stencil, a slice of "row" maps with string
+// key/values and the number of rows per page. It returns an error if it can't
+// find a Stencil with the passed ID or the page size isn't positive. Any passed
+// TableOptions are applied to the rows first, as per UseTableStencil.
+//
+// When the OutWriter is a terminal, PagedTable prints one page of the table at
+// a time with the headers and footer repeated on each page and the page number
+// (e.g. "page 2/7") beneath it, and prompts for the next page to show until the
+// user quits. Column widths and the footer are those of every row, so the
+// layout doesn't change from page to page. Otherwise it prints every row, as
+// per UseTableStencil.
+func PagedTable(id string, rows []map[string]string, pageSize int, opts ...*TableOptions) error {
+	return singleton.PagedTable(id, rows, pageSize, opts...)
+}
+
+// PagedTable takes the ID of a Table Stencil, a slice of "row" maps with string
+// key/values and the number of rows per page. It returns an error if it can't
+// find a Stencil with the passed ID or the page size isn't positive. Any passed
+// TableOptions are applied to the rows first, as per UseTableStencil.
+//
+// When the OutWriter is a terminal, PagedTable prints one page of the table at
+// a time with the headers and footer repeated on each page and the page number
+// (e.g. "page 2/7") beneath it, and prompts for the next page to show until the
+// user quits. Column widths and the footer are those of every row, so the
+// layout doesn't change from page to page. Otherwise it prints every row, as
+// per UseTableStencil.
+func (p *Printer) PagedTable(id string, rows []map[string]string, pageSize int, opts ...*TableOptions) error {
+	if pageSize <= 0 {
+		return fmt.Errorf("Page size must be greater than 0")
+	}
+	if !terminal.IsTerminal(p.OutWriter) {
+		return p.UseTableStencil(id, rows, opts...)
+	}
+	rows, origins, groups, err := prepareRows(rows, opts)
+	if err != nil {
+		return err
+	}
+	if p.outputFormat != "" && p.outputFormat != TableOutput {
+		return originalRow(p.ExportTable(id, rows, p.outputFormat, p.OutWriter), origins)
+	}
+	table, err := p.tabulateTable(id, rows, groups, p.rowStyle(opts))
+	if err != nil {
+		return originalRow(err, origins)
+	}
+	return p.navigateTable(table, pageSize)
+}
+
+// TablePage takes the ID of a Table Stencil, a slice of "row" maps with string
+// key/values, a page number starting from 1 and the number of rows per page
+// (e.g. from --page and --limit flags). It prints the rows of that page with
+// the headers and footer, followed by a summary of the rows shown and how many
+// were omitted. Column widths and the footer are those of every row. It
+// returns an error if it can't find a Stencil with the passed ID, the limit
+// isn't positive or the page is out of range. Any passed TableOptions are
+// applied to the rows first, and if the output format isn't TableOutput the
+// rows of the page are exported in it instead, as per UseTableStencil.
+func TablePage(id string, rows []map[string]string, page, limit int, opts ...*TableOptions) error {
+	return singleton.TablePage(id, rows, page, limit, opts...)
+}
+
+// TablePage takes the ID of a Table Stencil, a slice of "row" maps with string
+// key/values, a page number starting from 1 and the number of rows per page
+// (e.g. from --page and --limit flags). It prints the rows of that page with
+// the headers and footer, followed by a summary of the rows shown and how many
+// were omitted. Column widths and the footer are those of every row. It
+// returns an error if it can't find a Stencil with the passed ID, the limit
+// isn't positive or the page is out of range. Any passed TableOptions are
+// applied to the rows first, and if the output format isn't TableOutput the
+// rows of the page are exported in it instead, as per UseTableStencil.
+func (p *Printer) TablePage(id string, rows []map[string]string, page, limit int, opts ...*TableOptions) error {
+	rows, origins, groups, err := prepareRows(rows, opts)
+	if err != nil {
+		return err
+	}
+	pages, err := pageCount(len(rows), limit)
+	if err != nil {
+		return err
+	}
+	if page < 1 || page > pages {
+		return fmt.Errorf("Page %v is out of range, there are %v pages", page, pages)
+	}
+	start, end := pageBounds(page-1, limit, len(rows))
+	if p.outputFormat != "" && p.outputFormat != TableOutput {
+		return originalRow(p.ExportTable(id, rows[start:end], p.outputFormat, p.OutWriter), origins[start:end])
+	}
+	table, err := p.tabulateTable(id, rows, groups, p.rowStyle(opts))
+	if err != nil {
+		return originalRow(err, origins)
+	}
+	p.printPage(table, start, end)
+	if omitted := len(rows) - (end - start); omitted > 0 {
+		fmt.Fprintf(p.OutWriter, "page %d/%d, rows %d-%d of %d (%d omitted)\n", page, pages, start+1, end, len(rows), omitted)
+	}
+	return nil
+}
+
+// navigateTable prints one page of the tabulated table at a time, prompting for
+// the next page to show
+func (p *Printer) navigateTable(table *tabulatedTable, pageSize int) error {
+	pages, err := pageCount(len(table.rows), pageSize)
+	if err != nil {
+		return err
+	}
+	page := 0
+	for {
+		start, end := pageBounds(page, pageSize, len(table.rows))
+		p.printPage(table, start, end)
+		if pages == 1 {
+			return nil
+		}
+		options := []string{}
+		if page < pages-1 {
+			options = append(options, nextPage)
+		}
+		if page > 0 {
+			options = append(options, previousPage)
+		}
+		options = append(options, firstPage, lastPage, quitPaging)
+		i, err := p.prompter.Select(fmt.Sprintf("page %d/%d", page+1, pages), options)
+		if err != nil {
+			return err
+		}
+		switch options[i] {
+		case nextPage:
+			page++
+		case previousPage:
+			page--
+		case firstPage:
+			page = 0
+		case lastPage:
+			page = pages - 1
+		default:
+			return nil
+		}
+	}
+}
+
+// printPage prints the lines of the tabulated table's rows from start to end,
+// with its headers and footer
+func (p *Printer) printPage(table *tabulatedTable, start, end int) {
+	for _, line := range table.lines(start, end) {
+		fmt.Fprintln(p.OutWriter, line)
+	}
+}
+
+func pageCount(rows, pageSize int) (int, error) {
+	if pageSize <= 0 {
+		return 0, fmt.Errorf("Page size must be greater than 0")
+	}
+	if rows == 0 {
+		return 1, nil
+	}
+	return (rows + pageSize - 1) / pageSize, nil
+}
+
+// pageBounds returns the start and end index of the rows on the passed
+// zero-based page
+func pageBounds(page, pageSize, rows int) (start, end int) {
+	start = page * pageSize
+	end = start + pageSize
+	if end > rows {
+		end = rows
+	}
+	return start, end
+}
@@ -0,0 +1,142 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func pagedRows(n int) []map[string]string {
+	rows := make([]map[string]string, n)
+	for i := range rows {
+		rows[i] = map[string]string{"n": fmt.Sprint(i + 1)}
+	}
+	return rows
+}
+
+func pagedTable(rows int) *stenciller.StencilledTable {
+	table := &stenciller.StencilledTable{Rows: [][]string{{"N"}, {"--"}}, HeaderRows: 2, FooterRows: 2}
+	for i := 1; i <= rows; i++ {
+		table.Rows = append(table.Rows, []string{fmt.Sprint(i)})
+	}
+	table.Rows = append(table.Rows, []string{"--"}, []string{"15"})
+	return table
+}
+
+func pagedLines(table *stenciller.StencilledTable) []string {
+	lines := []string{}
+	for _, row := range table.Rows {
+		lines = append(lines, row[0])
+	}
+	return lines
+}
+
+func (suite *PrinterSuite) TestTablePage() {
+	id := "test id"
+	rows := pagedRows(5)
+	table := pagedTable(5)
+	suite.Stenciller.On("StencilTable", id, rows).Return(table, nil)
+	suite.Formatter.On("Tabulate", table.Rows, mock.Anything).Return(pagedLines(table))
+	err := TablePage(id, rows, 2, 2)
+	suite.NoError(err)
+	for _, line := range []string{"N", "--", "3", "4", "15", "page 2/3, rows 3-4 of 5 (3 omitted)"} {
+		suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln(line))
+	}
+	suite.OutWriter.AssertNotCalled(suite.T(), "Write", fmt.Sprintln("1"))
+	suite.OutWriter.AssertNotCalled(suite.T(), "Write", fmt.Sprintln("5"))
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 7)
+}
+
+func (suite *PrinterSuite) TestTablePageWithAllRows() {
+	id := "test id"
+	rows := pagedRows(2)
+	table := &stenciller.StencilledTable{Rows: [][]string{{"1"}, {"2"}}}
+	suite.Stenciller.On("StencilTable", id, rows).Return(table, nil)
+	suite.Formatter.On("Tabulate", table.Rows, mock.Anything).Return([]string{"1", "2"})
+	err := TablePage(id, rows, 1, 10)
+	suite.NoError(err)
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 2)
+}
+
+func (suite *PrinterSuite) TestTablePageWithOptions() {
+	id := "test id"
+	rows := pagedRows(5)
+	table := &stenciller.StencilledTable{Rows: [][]string{{"5"}, {"4"}, {"3"}}}
+	suite.Stenciller.On("StencilTable", id, []map[string]string{rows[4], rows[3], rows[2]}).Return(table, nil)
+	suite.Formatter.On("Tabulate", table.Rows, mock.Anything).Return([]string{"5", "4", "3"})
+	suite.Formatter.On("Style", "4", "dim").Return("dim 4")
+	err := TablePage(id, rows, 1, 2, &TableOptions{
+		Where:  "n > 2",
+		Sort:   []*SortKey{{Column: "n", Descending: true, Comparator: NumericCompare}},
+		Stripe: "dim",
+	})
+	suite.NoError(err)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("5"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("dim 4"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("page 1/2, rows 1-2 of 3 (1 omitted)"))
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 3)
+}
+
+func (suite *PrinterSuite) TestTablePageWithOutputFormat() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	id := "test id"
+	rows := pagedRows(5)
+	suite.Stenciller.On("TableData", id, rows[2:4]).Return(&stenciller.Table{
+		Headers: []string{"N"},
+		Columns: []string{"n"},
+		Rows:    [][]string{{"3"}, {"4"}},
+	}, nil)
+	SetOutputFormat(CSV)
+	err := TablePage(id, rows, 2, 2)
+	suite.NoError(err)
+	suite.Equal("N\r\n3\r\n4\r\n", out.String())
+}
+
+func (suite *PrinterSuite) TestTablePageOutOfRange() {
+	err := TablePage("test id", pagedRows(5), 4, 2)
+	suite.EqualError(err, "Page 4 is out of range, there are 3 pages")
+	err = TablePage("test id", pagedRows(5), 1, 0)
+	suite.Error(err)
+}
+
+func (suite *PrinterSuite) TestPagedTableWhenNotTerminal() {
+	id := "test id"
+	rows := pagedRows(3)
+	suite.Stenciller.On("UseTableStencil", id, rows).Return([][]string{{"1"}, {"2"}, {"3"}}, nil)
+	suite.Formatter.On("Tabulate", mock.Anything, mock.Anything).Return([]string{"1", "2", "3"})
+	err := PagedTable(id, rows, 2)
+	suite.NoError(err)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("3"))
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 3)
+	suite.Prompter.AssertNotCalled(suite.T(), "Select", mock.Anything, mock.Anything)
+	suite.Error(PagedTable(id, rows, 0))
+}
+
+func (suite *PrinterSuite) TestNavigateTable() {
+	table := &tabulatedTable{
+		header: []string{"N"},
+		rows:   [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+		footer: []string{"15"},
+	}
+	suite.Prompter.On("Select", "page 1/3", []string{nextPage, firstPage, lastPage, quitPaging}).Return(2, nil)
+	suite.Prompter.On("Select", "page 3/3", []string{previousPage, firstPage, lastPage, quitPaging}).Return(0, nil)
+	suite.Prompter.On("Select", "page 2/3", []string{nextPage, previousPage, firstPage, lastPage, quitPaging}).Return(4, nil)
+	err := singleton.navigateTable(table, 2)
+	suite.NoError(err)
+	for _, line := range []string{"1", "2", "3", "4", "5"} {
+		suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln(line))
+	}
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 11)
+	suite.Prompter.AssertNumberOfCalls(suite.T(), "Select", 3)
+}
+
+func (suite *PrinterSuite) TestNavigateTableWithPromptError() {
+	table := &tabulatedTable{rows: [][]string{{"1"}, {"2"}, {"3"}}}
+	suite.Prompter.On("Select", mock.Anything, mock.Anything).Return(0, errors.New("interrupt"))
+	err := singleton.navigateTable(table, 2)
+	suite.EqualError(err, "interrupt")
+}
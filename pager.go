@@ -0,0 +1,86 @@
+package printer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tomguerney/printer/internal/pager"
+	"github.com/tomguerney/printer/internal/terminal"
+)
+
+// Pager modes
+const (
+	PagerAuto   = "auto"
+	PagerAlways = "always"
+	PagerNever  = "never"
+)
+
+// SetPagerMode sets when long output is paged. With PagerAuto (the default),
+// tables, template results and lists taller than the terminal are piped through
+// the pager. With PagerAlways they are always paged, and with PagerNever they
+// never are. Output is never paged when the OutWriter isn't a terminal.
+//
+// The pager is the command set in the PAGER environment variable, falling back
+// to "less -R", and falling back to a simple built-in pager if neither is
+// available.
+func SetPagerMode(mode string) {
+	singleton.SetPagerMode(mode)
+}
+
+// SetPagerMode sets when long output is paged. With PagerAuto (the default),
+// tables, template results and lists taller than the terminal are piped through
+// the pager. With PagerAlways they are always paged, and with PagerNever they
+// never are. Output is never paged when the OutWriter isn't a terminal.
+//
+// The pager is the command set in the PAGER environment variable, falling back
+// to "less -R", and falling back to a simple built-in pager if neither is
+// available.
+func (p *Printer) SetPagerMode(mode string) {
+	p.pagerMode = mode
+}
+
+// WithPager returns a copy of the Printer that uses the passed pager mode, for
+// controlling paging of a single call, e.g.
+// WithPager(PagerAlways).UseTableStencil(id, rows)
+func WithPager(mode string) *Printer {
+	return singleton.WithPager(mode)
+}
+
+// WithPager returns a copy of the Printer that uses the passed pager mode, for
+// controlling paging of a single call, e.g.
+// WithPager(PagerAlways).UseTableStencil(id, rows)
+func (p *Printer) WithPager(mode string) *Printer {
+	child := *p
+	child.pagerMode = mode
+	return &child
+}
+
+// printLines prints the passed lines to the OutWriter, through the pager if the
+// pager mode requires it. If the pager fails, the lines are printed directly.
+func (p *Printer) printLines(lines []string) {
+	if p.shouldPage(lines) {
+		text := strings.Join(lines, "\n") + "\n"
+		height := terminal.Height(p.OutWriter)
+		if err := pager.New(p.OutWriter, os.Stdin, height).Page(text); err == nil {
+			return
+		}
+	}
+	for _, line := range lines {
+		fmt.Fprintln(p.OutWriter, line)
+	}
+}
+
+func (p *Printer) shouldPage(lines []string) bool {
+	if p.pagerMode == PagerNever || !terminal.IsTerminal(p.OutWriter) {
+		return false
+	}
+	if p.pagerMode == PagerAlways {
+		return true
+	}
+	height := 0
+	for _, line := range lines {
+		height += strings.Count(line, "\n") + 1
+	}
+	return height >= terminal.Height(p.OutWriter)
+}
@@ -0,0 +1,18 @@
+package printer
+
+import "fmt"
+
+func (suite *PrinterSuite) TestWithPager() {
+	child := WithPager(PagerAlways)
+	suite.Equal(PagerAlways, child.pagerMode)
+	suite.Equal(PagerAuto, singleton.pagerMode)
+}
+
+func (suite *PrinterSuite) TestNoPagingWhenNotTerminal() {
+	SetPagerMode(PagerAlways)
+	lines := []string{"row1", "row2"}
+	suite.False(singleton.shouldPage(lines))
+	singleton.printLines(lines)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("row1"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("row2"))
+}
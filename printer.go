@@ -6,17 +6,28 @@ import (
 	"os"
 
 	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/locale"
 	"github.com/tomguerney/printer/internal/prompter"
 	"github.com/tomguerney/printer/internal/stenciller"
+	"github.com/tomguerney/printer/internal/templater"
+	"github.com/tomguerney/printer/internal/terminal"
 )
 
 // Printer prints formatted and stencilled strings to the set io.Writer
 type Printer struct {
-	OutWriter  io.Writer
-	ErrWriter  io.Writer
-	formatter  Formatter
-	stenciller Stenciller
-	prompter   Prompter
+	OutWriter    io.Writer
+	ErrWriter    io.Writer
+	formatter    Formatter
+	stenciller   Stenciller
+	prompter     Prompter
+	width        int
+	autoWrap     bool
+	pagerMode    string
+	locale       *locale.Locale
+	verbose      bool
+	quiet        bool
+	outputFormat string
+	tableStyle   string
 }
 
 // Colors
@@ -31,11 +42,28 @@ const (
 	White   = "white"
 )
 
+// Missing key policies
+const (
+	MissingKeyEmpty = templater.MissingEmpty
+	MissingKeyKeep  = templater.MissingKeep
+	MissingKeyError = templater.MissingError
+)
+
 // Formatter formats strings for simple and consistent output
 type Formatter interface {
 	Text(interface{}, ...interface{}) string
 	Tabulate(rows [][]string, headers ...string) []string
+	List(items []*formatter.ListItem, opts *formatter.ListOptions) []string
+	Box(title, body string, opts *formatter.BoxOptions) []string
+	Heading(level int, text string, width int) []string
+	Rule(width int) string
+	Wrap(text string, opts *formatter.WrapOptions) []string
+	Style(line, style string) string
+	Diff(oldText, newText string, opts *formatter.DiffOptions) []string
+	DiffMaps(oldMap, newMap map[string]string, opts *formatter.DiffOptions) []string
+	NewTableStream(w io.Writer, headers []string, opts *formatter.StreamOptions) *formatter.TableStream
 	SetTabwriterOptions(twOptions *formatter.TabwriterOptions)
+	TabwriterOptions() *formatter.TabwriterOptions
 }
 
 // Stenciller formats "data" maps of string key/value pairs according to
@@ -43,9 +71,22 @@ type Formatter interface {
 type Stenciller interface {
 	AddTemplateStencil(*stenciller.TemplateStencil) error
 	AddTableStencil(*stenciller.TableStencil) error
+	AddListStencil(*stenciller.ListStencil) error
+	AddPartial(name, text string) error
+	Load(source string, set *stenciller.Set) error
+	ResolveTableStencil(id string) (*stenciller.TableStencil, error)
 	UseTemplateStencil(id string, data map[string]string) (string, error)
 	UseTableStencil(id string, rows []map[string]string) ([][]string, error)
+	StencilTable(id string, rows []map[string]string) (*stenciller.StencilledTable, error)
+	UseTableStencilRow(id string, row map[string]string) ([]string, error)
+	UseListStencil(id string, items []map[string]string) ([]string, error)
+	TableData(id string, rows []map[string]string) (*stenciller.Table, error)
 	Color(text, color string) (string, bool)
+	SetLocale(l *locale.Locale)
+	SetMissingKey(policy string)
+	SetStrict(strict bool)
+	AddCatalog(tag string, messages map[string]string)
+	Message(text string) string
 }
 
 // Prompter gets input from the user
@@ -53,29 +94,62 @@ type Prompter interface {
 	Select(label string, table []string) (i int, err error)
 }
 
+// ValidationError is the error returned when adding a Stencil with problems,
+// such as an unknown color or a different number of headers than columns. Kind
+// is the kind of Stencil ("template", "table" or "list"), and Problems lists
+// every problem found.
+type ValidationError = stenciller.ValidationError
+
+// DataError is the error returned when a row of data has a key that isn't a
+// column of a Table Stencil, or is missing a column, and the Stencil's policy
+// for it is PolicyError. Row is the index of the row in the rows passed, before
+// any TableOptions are applied to them.
+type DataError = stenciller.DataError
+
+// Data policies
+const (
+	PolicyIgnore = stenciller.PolicyIgnore
+	PolicyWarn   = stenciller.PolicyWarn
+	PolicyError  = stenciller.PolicyError
+)
+
 // TemplateStencil is
 type TemplateStencil struct {
-	ID       string
-	Template string
-	Colors   map[string]string
+	ID        string
+	Template  string
+	Colors    map[string]string
+	Formats   map[string]*ValueFormat
+	Localized map[string]string
 }
 
 // TableStencil is
 type TableStencil struct {
-	ID          string
-	Colors      map[string]string
-	ColumnOrder []string
-	Headers     []string
+	ID               string
+	Colors           map[string]string
+	ColumnOrder      []string
+	Headers          []string
+	Footer           map[string]string
+	FooterFuncs      map[string]func(values []string) (string, error)
+	FooterLabel      string
+	Widths           []int
+	Formats          map[string]*ValueFormat
+	LocalizedHeaders map[string][]string
+	Defaults         map[string]string
+	UnknownKeys      string
+	MissingKeys      string
+	Extends          string
+	RemoveColumns    []string
 }
 
 // New a new printer
 func New() *Printer {
 	return &Printer{
-		os.Stdout,
-		os.Stderr,
-		formatter.New(),
-		stenciller.New(),
-		prompter.New(),
+		OutWriter:  os.Stdout,
+		ErrWriter:  os.Stderr,
+		formatter:  formatter.New(),
+		stenciller: stenciller.New(),
+		prompter:   prompter.New(),
+		pagerMode:  PagerAuto,
 	}
 }
 
@@ -103,7 +177,80 @@ func SetErrWriter(writer io.Writer) {
 
 // SetErrWriter sets the ErrWriter
 func (p *Printer) SetErrWriter(writer io.Writer) {
-	p.OutWriter = writer
+	p.ErrWriter = writer
+}
+
+// SetPrompter sets the Prompter used to get input from the user, such as a
+// scripted Prompter in tests
+func SetPrompter(prompter Prompter) {
+	singleton.SetPrompter(prompter)
+}
+
+// SetPrompter sets the Prompter used to get input from the user, such as a
+// scripted Prompter in tests
+func (p *Printer) SetPrompter(prompter Prompter) {
+	p.prompter = prompter
+}
+
+// SetWidth sets the width in columns that output is wrapped and sized to. A
+// width of 0 or less uses the width of the terminal.
+func SetWidth(width int) {
+	singleton.SetWidth(width)
+}
+
+// SetWidth sets the width in columns that output is wrapped and sized to. A
+// width of 0 or less uses the width of the terminal.
+func (p *Printer) SetWidth(width int) {
+	p.width = width
+}
+
+// Width returns the width in columns that output is wrapped and sized to
+func Width() int {
+	return singleton.Width()
+}
+
+// Width returns the width in columns that output is wrapped and sized to
+func (p *Printer) Width() int {
+	if p.width > 0 {
+		return p.width
+	}
+	return terminal.Width(p.OutWriter)
+}
+
+// SetMissingKey sets what keys of Template and List Stencils that are missing
+// from a data map are replaced with. With MissingKeyEmpty (the default) they
+// are replaced with an empty string, with MissingKeyKeep they are left as their
+// placeholder, e.g. "{{.name}}", and with MissingKeyError applying the Stencil
+// returns an error.
+func SetMissingKey(policy string) {
+	singleton.SetMissingKey(policy)
+}
+
+// SetMissingKey sets what keys of Template and List Stencils that are missing
+// from a data map are replaced with. With MissingKeyEmpty (the default) they
+// are replaced with an empty string, with MissingKeyKeep they are left as their
+// placeholder, e.g. "{{.name}}", and with MissingKeyError applying the Stencil
+// returns an error.
+func (p *Printer) SetMissingKey(policy string) {
+	p.stenciller.SetMissingKey(policy)
+}
+
+// SetStrict sets whether Stencils with problems are rejected. When lenient (the
+// default), the problems are logged as warnings and the Stencil is added
+// anyway, unless its template can't be parsed or it extends itself. When
+// strict, adding a Stencil with any problem returns a *ValidationError listing
+// them.
+func SetStrict(strict bool) {
+	singleton.SetStrict(strict)
+}
+
+// SetStrict sets whether Stencils with problems are rejected. When lenient (the
+// default), the problems are logged as warnings and the Stencil is added
+// anyway, unless its template can't be parsed or it extends itself. When
+// strict, adding a Stencil with any problem returns a *ValidationError listing
+// them.
+func (p *Printer) SetStrict(strict bool) {
+	p.stenciller.SetStrict(strict)
 }
 
 // SetTabwriterOptions sets tabwriter options
@@ -127,7 +274,7 @@ func Out(i interface{}, a ...interface{}) {
 // contains formatting verbs (e.g. %v), they will be formatted as per the
 // "...interface{}" variadic parameter in the fashion of fmt.Printf()
 func (p *Printer) Out(i interface{}, a ...interface{}) {
-	fmt.Fprintf(p.OutWriter, p.formatter.Text(i, a...))
+	fmt.Fprint(p.OutWriter, p.text(i, a...))
 }
 
 // Err prints the passed text prefixed with "Error: " and appended with a
@@ -143,7 +290,7 @@ func Err(i interface{}, a ...interface{}) {
 // formatted as per the "...interface{}" variadic parameter in the fashion of
 // fmt.Printf()
 func (p *Printer) Err(i interface{}, a ...interface{}) {
-	fmt.Fprintf(p.ErrWriter, p.formatter.Text(i, a...))
+	fmt.Fprint(p.ErrWriter, p.text(i, a...))
 }
 
 // Feed prints an empty line to the OutWriter
@@ -174,6 +321,8 @@ func (p *Printer) Color(text, color string) string {
 //
 // Tabulate prints each row from the original 2D slice spaced such that each
 // element in each row appear vertically aligned in equally-spaced columns.
+// A cell containing newlines spans as many lines as it needs, with the other
+// cells in its row padded vertically to keep the columns aligned.
 func Tabulate(rows [][]string, headers ...string) {
 	singleton.Tabulate(rows, headers...)
 }
@@ -185,11 +334,10 @@ func Tabulate(rows [][]string, headers ...string) {
 //
 // Tabulate prints each row from the original 2D slice spaced such that each
 // element in each row appear vertically aligned in equally-spaced columns.
+// A cell containing newlines spans as many lines as it needs, with the other
+// cells in its row padded vertically to keep the columns aligned.
 func (p *Printer) Tabulate(rows [][]string, headers ...string) {
-	tabulated := p.formatter.Tabulate(rows, headers...)
-	for _, row := range tabulated {
-		fmt.Fprintln(p.OutWriter, row)
-	}
+	p.printLines(p.formatter.Tabulate(rows, headers...))
 }
 
 // UseTemplateStencil takes the ID of a Template Stencil and a "data" map with string
@@ -221,7 +369,7 @@ func (p *Printer) UseTemplateStencil(id string, data map[string]string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Fprintln(p.OutWriter, result)
+	p.printLines([]string{result})
 	return nil
 }
 
@@ -236,8 +384,13 @@ func (p *Printer) UseTemplateStencil(id string, data map[string]string) error {
 // that matches a key in the Stencil's color map and transforms the data value
 // string to the color of the color value. It returns the rows and columns as a
 // 2D string slice with a prefixed header row.
-func UseTableStencil(id string, rows []map[string]string) error {
-	return singleton.UseTableStencil(id, rows)
+//
+// Any passed TableOptions are applied to the rows first, to filter, sort and
+// group them. It returns an error if a filter expression or sort comparator is
+// invalid. If the output format isn't TableOutput, the rows are exported in it
+// instead, as per ExportTable.
+func UseTableStencil(id string, rows []map[string]string, opts ...*TableOptions) error {
+	return singleton.UseTableStencil(id, rows, opts...)
 }
 
 // UseTableStencil takes the ID of a Table Stencil and a slice of "row" maps with
@@ -251,44 +404,210 @@ func UseTableStencil(id string, rows []map[string]string) error {
 // that matches a key in the Stencil's color map and transforms the data value
 // string to the color of the color value. It returns the rows and columns as a
 // 2D string slice with a prefixed header row.
-func (p *Printer) UseTableStencil(id string, rows []map[string]string) error {
-	result, err := p.stenciller.UseTableStencil(id, rows)
+//
+// Any passed TableOptions are applied to the rows first, to filter, sort and
+// group them. It returns an error if a filter expression or sort comparator is
+// invalid. If the output format isn't TableOutput, the rows are exported in it
+// instead, as per ExportTable.
+func (p *Printer) UseTableStencil(id string, rows []map[string]string, opts ...*TableOptions) error {
+	rows, origins, groups, err := prepareRows(rows, opts)
 	if err != nil {
 		return err
 	}
-	p.Tabulate(result)
+	if p.outputFormat != "" && p.outputFormat != TableOutput {
+		return originalRow(p.ExportTable(id, rows, p.outputFormat, p.OutWriter), origins)
+	}
+	style := p.rowStyle(opts)
+	if groups == nil && style.empty() {
+		result, err := p.stenciller.UseTableStencil(id, rows)
+		if err != nil {
+			return originalRow(err, origins)
+		}
+		p.Tabulate(result)
+		return nil
+	}
+	table, err := p.tabulateTable(id, rows, groups, style)
+	if err != nil {
+		return originalRow(err, origins)
+	}
+	p.printLines(table.lines(0, len(table.rows)))
 	return nil
 }
 
-// AddTemplateStencil adds a new Template Stencil with the passed ID and colors.
+// AddTemplateStencil adds a new Template Stencil with the passed ID, colors and
+// value formats. It returns a *ValidationError if the template can't be
+// parsed, giving the position of the error in the template, or, if strict as
+// per SetStrict, if a color or value format is unknown or for a key the
+// template doesn't use.
+// Localized holds variants of the template for other locales, keyed by locale
+// tag, which are used instead of the template when the locale is set.
+//
+// Besides the functions of the "text/template" package, templates can use
+// "number" to localize a number, e.g. {{ number .count }}, "plural" to choose
+// between "one" and "other" forms (or "one", "few", "many" and "other" forms)
+// for a number, with "#" replaced by the number, e.g.
+// {{ plural .count "# file" "# files" }}, and "translate" to translate text by
+// the message catalog of the locale. Templates can also include partials added
+// with AddPartial, e.g. {{ template "header" . }}, and if strict it returns a
+// *ValidationError if a partial hasn't been added.
 func AddTemplateStencil(stencil *TemplateStencil) error {
 	return singleton.AddTemplateStencil(stencil)
 }
 
-// AddTemplateStencil adds a new Template Stencil with the passed ID and colors.
+// AddTemplateStencil adds a new Template Stencil with the passed ID, colors and
+// value formats. It returns a *ValidationError if the template can't be
+// parsed, giving the position of the error in the template, or, if strict as
+// per SetStrict, if a color or value format is unknown or for a key the
+// template doesn't use.
+// Localized holds variants of the template for other locales, keyed by locale
+// tag, which are used instead of the template when the locale is set.
+//
+// Besides the functions of the "text/template" package, templates can use
+// "number" to localize a number, e.g. {{ number .count }}, "plural" to choose
+// between "one" and "other" forms (or "one", "few", "many" and "other" forms)
+// for a number, with "#" replaced by the number, e.g.
+// {{ plural .count "# file" "# files" }}, and "translate" to translate text by
+// the message catalog of the locale. Templates can also include partials added
+// with AddPartial, e.g. {{ template "header" . }}, and if strict it returns a
+// *ValidationError if a partial hasn't been added.
 func (p *Printer) AddTemplateStencil(stencil *TemplateStencil) error {
-	return p.stenciller.AddTemplateStencil(&stenciller.TemplateStencil{
-		ID:       stencil.ID,
-		Template: stencil.Template,
-		Colors:   stencil.Colors,
-	})
+	return p.stenciller.AddTemplateStencil(toStencillerTemplate(stencil))
 }
 
 // AddTableStencil adds a new table Stencil with the passed ID, headers, and
-// colors.
+// colors. If strict as per SetStrict, it returns a *ValidationError if it has
+// duplicate columns, a different number of headers or widths than columns, an
+// unknown color, value format or aggregate, or a color, format or footer for a
+// column that isn't in its column order.
+//
+// A Table Stencil may also declare a footer, which is rendered below a second
+// divider row. Footer maps columns to one of the Sum, Avg, Min, Max or Count
+// aggregates, and FooterFuncs maps columns to custom aggregate functions that
+// are passed every value in the column. FooterLabel is shown in the first
+// column of the footer if that column isn't aggregated. Sum, Avg, Min and Max
+// parse numbers with a currency symbol prefix such as "$3.50" or a unit suffix
+// such as "45%", "12MB" or "3GiB", ignore empty values, and cause
+// UseTableStencil to return an error if a value can't be parsed.
+//
+// Widths are fixed column widths used when the table is streamed with
+// StreamTableStencil. Formats are the ValueFormats of the columns, such as
+// formatting a column of byte counts as "1.5 KiB". Sum, Avg, Min and Max
+// footers are formatted with their column's format.
+//
+// LocalizedHeaders are variants of the headers for other locales, keyed by
+// locale tag, which are used instead of the headers when the locale is set.
+//
+// Defaults are the values of columns that are missing or empty in a row. Keys
+// of a row that aren't in the column order are ignored, and other columns
+// missing from a row are left empty, unless UnknownKeys or MissingKeys is
+// PolicyWarn, which logs a warning, or PolicyError, which makes using the
+// Stencil return a *DataError with the index of the row and the key.
+//
+// Extends is the ID of a Table Stencil to inherit from, which is found when the
+// Stencil is used. The column order replaces the inherited one, if set, and
+// RemoveColumns are removed from the columns. Headers, localized headers and
+// widths are inherited for each column unless set, with new columns headed by
+// their key. Colors, formats, footers, footer functions and defaults are
+// inherited and overridden by those set, where an empty color removes an
+// inherited color. It returns a *ValidationError if it extends itself, or if
+// strict, if a Stencil it extends can't be found. ResolvedTableStencil returns
+// the result.
 func AddTableStencil(stencil *TableStencil) error {
 	return singleton.AddTableStencil(stencil)
 }
 
 // AddTableStencil adds a new table Stencil with the passed ID, headers, and
-// colors.
+// colors. If strict as per SetStrict, it returns a *ValidationError if it has
+// duplicate columns, a different number of headers or widths than columns, an
+// unknown color, value format or aggregate, or a color, format or footer for a
+// column that isn't in its column order.
+//
+// A Table Stencil may also declare a footer, which is rendered below a second
+// divider row. Footer maps columns to one of the Sum, Avg, Min, Max or Count
+// aggregates, and FooterFuncs maps columns to custom aggregate functions that
+// are passed every value in the column. FooterLabel is shown in the first
+// column of the footer if that column isn't aggregated. Sum, Avg, Min and Max
+// parse numbers with a currency symbol prefix such as "$3.50" or a unit suffix
+// such as "45%", "12MB" or "3GiB", ignore empty values, and cause
+// UseTableStencil to return an error if a value can't be parsed.
+//
+// Widths are fixed column widths used when the table is streamed with
+// StreamTableStencil. Formats are the ValueFormats of the columns, such as
+// formatting a column of byte counts as "1.5 KiB". Sum, Avg, Min and Max
+// footers are formatted with their column's format.
+//
+// LocalizedHeaders are variants of the headers for other locales, keyed by
+// locale tag, which are used instead of the headers when the locale is set.
+//
+// Defaults are the values of columns that are missing or empty in a row. Keys
+// of a row that aren't in the column order are ignored, and other columns
+// missing from a row are left empty, unless UnknownKeys or MissingKeys is
+// PolicyWarn, which logs a warning, or PolicyError, which makes using the
+// Stencil return a *DataError with the index of the row and the key.
+//
+// Extends is the ID of a Table Stencil to inherit from, which is found when the
+// Stencil is used. The column order replaces the inherited one, if set, and
+// RemoveColumns are removed from the columns. Headers, localized headers and
+// widths are inherited for each column unless set, with new columns headed by
+// their key. Colors, formats, footers, footer functions and defaults are
+// inherited and overridden by those set, where an empty color removes an
+// inherited color. It returns a *ValidationError if it extends itself, or if
+// strict, if a Stencil it extends can't be found. ResolvedTableStencil returns
+// the result.
 func (p *Printer) AddTableStencil(stencil *TableStencil) error {
-	return p.stenciller.AddTableStencil(&stenciller.TableStencil{
-		ID:          stencil.ID,
-		Colors:      stencil.Colors,
-		ColumnOrder: stencil.ColumnOrder,
-		Headers:     stencil.Headers,
-	})
+	return p.stenciller.AddTableStencil(toStencillerTable(stencil))
+}
+
+// ResolvedTableStencil returns the Table Stencil with the passed ID with
+// everything it inherits from the Stencils it extends, as it is used. It
+// returns an error if it can't find the Stencil or a Stencil it extends, or if
+// the Stencil extends itself.
+func ResolvedTableStencil(id string) (*TableStencil, error) {
+	return singleton.ResolvedTableStencil(id)
+}
+
+// ResolvedTableStencil returns the Table Stencil with the passed ID with
+// everything it inherits from the Stencils it extends, as it is used. It
+// returns an error if it can't find the Stencil or a Stencil it extends, or if
+// the Stencil extends itself.
+func (p *Printer) ResolvedTableStencil(id string) (*TableStencil, error) {
+	stencil, err := p.stenciller.ResolveTableStencil(id)
+	if err != nil {
+		return nil, err
+	}
+	return &TableStencil{
+		ID:               stencil.ID,
+		Colors:           stencil.Colors,
+		ColumnOrder:      stencil.ColumnOrder,
+		Headers:          stencil.Headers,
+		Footer:           stencil.Footer,
+		FooterFuncs:      stencil.FooterFuncs,
+		FooterLabel:      stencil.FooterLabel,
+		Widths:           stencil.Widths,
+		Formats:          valueFormats(stencil.Formats),
+		LocalizedHeaders: stencil.LocalizedHeaders,
+		Defaults:         stencil.Defaults,
+		UnknownKeys:      stencil.UnknownKeys,
+		MissingKeys:      stencil.MissingKeys,
+		Extends:          stencil.Extends,
+		RemoveColumns:    stencil.RemoveColumns,
+	}, nil
+}
+
+// AddPartial adds a partial template with the passed name, which the templates
+// of Template and List Stencils can include with {{ template "name" . }}.
+// Partials must be added before the Stencils that include them. It returns a
+// *ValidationError if the template can't be parsed.
+func AddPartial(name, template string) error {
+	return singleton.AddPartial(name, template)
+}
+
+// AddPartial adds a partial template with the passed name, which the templates
+// of Template and List Stencils can include with {{ template "name" . }}.
+// Partials must be added before the Stencils that include them. It returns a
+// *ValidationError if the template can't be parsed.
+func (p *Printer) AddPartial(name, template string) error {
+	return p.stenciller.AddPartial(name, template)
 }
 
 // Select selects
@@ -318,3 +637,33 @@ func (p *Printer) TemplateSelect(id, label string, rows []map[string]string) (i
 	}
 	return p.prompter.Select(label, results)
 }
+
+func toStencillerTemplate(stencil *TemplateStencil) *stenciller.TemplateStencil {
+	return &stenciller.TemplateStencil{
+		ID:        stencil.ID,
+		Template:  stencil.Template,
+		Colors:    stencil.Colors,
+		Formats:   humanizeFormats(stencil.Formats),
+		Localized: stencil.Localized,
+	}
+}
+
+func toStencillerTable(stencil *TableStencil) *stenciller.TableStencil {
+	return &stenciller.TableStencil{
+		ID:               stencil.ID,
+		Colors:           stencil.Colors,
+		ColumnOrder:      stencil.ColumnOrder,
+		Headers:          stencil.Headers,
+		Footer:           stencil.Footer,
+		FooterFuncs:      stencil.FooterFuncs,
+		FooterLabel:      stencil.FooterLabel,
+		Widths:           stencil.Widths,
+		Formats:          humanizeFormats(stencil.Formats),
+		LocalizedHeaders: stencil.LocalizedHeaders,
+		Defaults:         stencil.Defaults,
+		UnknownKeys:      stencil.UnknownKeys,
+		MissingKeys:      stencil.MissingKeys,
+		Extends:          stencil.Extends,
+		RemoveColumns:    stencil.RemoveColumns,
+	}
+}
@@ -3,11 +3,13 @@ package printer
 import (
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/locale"
 	"github.com/tomguerney/printer/internal/stenciller"
 )
 
@@ -36,10 +38,60 @@ func (m *MockFormatter) Tabulate(rows [][]string, headers ...string) []string {
 	return args.Get(0).([]string)
 }
 
+func (m *MockFormatter) List(items []*formatter.ListItem, opts *formatter.ListOptions) []string {
+	args := m.Called(items, opts)
+	return args.Get(0).([]string)
+}
+
+func (m *MockFormatter) Box(title, body string, opts *formatter.BoxOptions) []string {
+	args := m.Called(title, body, opts)
+	return args.Get(0).([]string)
+}
+
+func (m *MockFormatter) Heading(level int, text string, width int) []string {
+	args := m.Called(level, text, width)
+	return args.Get(0).([]string)
+}
+
+func (m *MockFormatter) Rule(width int) string {
+	args := m.Called(width)
+	return args.String(0)
+}
+
+func (m *MockFormatter) Wrap(text string, opts *formatter.WrapOptions) []string {
+	args := m.Called(text, opts)
+	return args.Get(0).([]string)
+}
+
+func (m *MockFormatter) Diff(oldText, newText string, opts *formatter.DiffOptions) []string {
+	args := m.Called(oldText, newText, opts)
+	return args.Get(0).([]string)
+}
+
+func (m *MockFormatter) DiffMaps(oldMap, newMap map[string]string, opts *formatter.DiffOptions) []string {
+	args := m.Called(oldMap, newMap, opts)
+	return args.Get(0).([]string)
+}
+
+func (m *MockFormatter) Style(line, style string) string {
+	args := m.Called(line, style)
+	return args.String(0)
+}
+
+func (m *MockFormatter) NewTableStream(w io.Writer, headers []string, opts *formatter.StreamOptions) *formatter.TableStream {
+	args := m.Called(w, headers, opts)
+	return args.Get(0).(*formatter.TableStream)
+}
+
 func (m *MockFormatter) SetTabwriterOptions(twOptions *formatter.TabwriterOptions) {
 	m.Called(twOptions)
 }
 
+func (m *MockFormatter) TabwriterOptions() *formatter.TabwriterOptions {
+	args := m.Called()
+	return args.Get(0).(*formatter.TabwriterOptions)
+}
+
 // MockStenciller is a mock templater for testing
 type MockStenciller struct {
 	mock.Mock
@@ -55,6 +107,26 @@ func (m *MockStenciller) AddTableStencil(stencil *stenciller.TableStencil) error
 	return args.Error(0)
 }
 
+func (m *MockStenciller) AddListStencil(stencil *stenciller.ListStencil) error {
+	args := m.Called(stencil)
+	return args.Error(0)
+}
+
+func (m *MockStenciller) AddPartial(name, text string) error {
+	args := m.Called(name, text)
+	return args.Error(0)
+}
+
+func (m *MockStenciller) Load(source string, set *stenciller.Set) error {
+	args := m.Called(source, set)
+	return args.Error(0)
+}
+
+func (m *MockStenciller) ResolveTableStencil(id string) (*stenciller.TableStencil, error) {
+	args := m.Called(id)
+	return args.Get(0).(*stenciller.TableStencil), args.Error(1)
+}
+
 func (m *MockStenciller) UseTemplateStencil(id string, data map[string]string) (string, error) {
 	args := m.Called(id, data)
 	return args.String(0), args.Error(1)
@@ -65,6 +137,48 @@ func (m *MockStenciller) UseTableStencil(id string, rows []map[string]string) ([
 	return args.Get(0).([][]string), args.Error(1)
 }
 
+func (m *MockStenciller) StencilTable(id string, rows []map[string]string) (*stenciller.StencilledTable, error) {
+	args := m.Called(id, rows)
+	table, _ := args.Get(0).(*stenciller.StencilledTable)
+	return table, args.Error(1)
+}
+
+func (m *MockStenciller) SetLocale(l *locale.Locale) {
+	m.Called(l)
+}
+
+func (m *MockStenciller) SetMissingKey(policy string) {
+	m.Called(policy)
+}
+
+func (m *MockStenciller) SetStrict(strict bool) {
+	m.Called(strict)
+}
+
+func (m *MockStenciller) AddCatalog(tag string, messages map[string]string) {
+	m.Called(tag, messages)
+}
+
+func (m *MockStenciller) Message(text string) string {
+	args := m.Called(text)
+	return args.String(0)
+}
+
+func (m *MockStenciller) UseTableStencilRow(id string, row map[string]string) ([]string, error) {
+	args := m.Called(id, row)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStenciller) UseListStencil(id string, items []map[string]string) ([]string, error) {
+	args := m.Called(id, items)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStenciller) TableData(id string, rows []map[string]string) (*stenciller.Table, error) {
+	args := m.Called(id, rows)
+	return args.Get(0).(*stenciller.Table), args.Error(1)
+}
+
 func (m *MockStenciller) Color(text, color string) (string, bool) {
 	args := m.Called(text, color)
 	return args.String(0), args.Bool(1)
@@ -97,7 +211,15 @@ func (suite *PrinterSuite) SetupTest() {
 	suite.Formatter = new(MockFormatter)
 	suite.Stenciller = new(MockStenciller)
 	suite.Prompter = new(MockPrompter)
-	singleton = &Printer{suite.OutWriter, suite.ErrWriter, suite.Formatter, suite.Stenciller, suite.Prompter}
+	singleton = &Printer{
+		OutWriter:  suite.OutWriter,
+		ErrWriter:  suite.ErrWriter,
+		formatter:  suite.Formatter,
+		stenciller: suite.Stenciller,
+		prompter:   suite.Prompter,
+		width:      80,
+		pagerMode:  PagerAuto,
+	}
 }
 
 func (suite *PrinterSuite) TestOut() {
@@ -194,6 +316,18 @@ func (suite *PrinterSuite) TestTmplStencilWithError() {
 	suite.OutWriter.AssertNotCalled(suite.T(), "Write", mock.Anything)
 }
 
+func (suite *PrinterSuite) TestSetMissingKey() {
+	suite.Stenciller.On("SetMissingKey", MissingKeyKeep).Return()
+	SetMissingKey(MissingKeyKeep)
+	suite.Stenciller.AssertCalled(suite.T(), "SetMissingKey", MissingKeyKeep)
+}
+
+func (suite *PrinterSuite) TestSetStrict() {
+	suite.Stenciller.On("SetStrict", true).Return()
+	SetStrict(true)
+	suite.Stenciller.AssertCalled(suite.T(), "SetStrict", true)
+}
+
 func (suite *PrinterSuite) TestTableStencil() {
 	id := "test id"
 	rows := []map[string]string{{"key": "value"}}
@@ -0,0 +1,210 @@
+// Package printertest records the output of a Printer for tests, and compares
+// it against golden files.
+//
+// A golden file holds the expected output of a test in the testdata directory
+// of the package under test. Running the tests with the -update flag writes the
+// actual output to the golden files instead of comparing against them, so
+// changes to output can be reviewed in the diff of the golden files. Packages
+// that use printertest can't define their own -update flag.
+//
+// Colors are turned on or off for every Printer while a Recorder is in use, so
+// tests that use a Recorder can't run in parallel.
+package printertest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/tomguerney/printer"
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// Options are the options used to create a Recorder. Width is the fixed width
+// of the terminal, and defaults to 80. If Color is true, colors are written as
+// ANSI escape codes even though the output isn't a terminal, and otherwise
+// they are never written. Answers are the answers the Recorder's Prompter
+// gives to prompts, in order.
+type Options struct {
+	Width   int
+	Color   bool
+	Answers []string
+}
+
+// Recorder is a Printer that records what is printed to its OutWriter and
+// ErrWriter. Output is never paged.
+type Recorder struct {
+	*printer.Printer
+	Prompter *Prompter
+	out      *bytes.Buffer
+	err      *bytes.Buffer
+}
+
+// New returns a new Recorder. If opts is nil the default Options are used.
+// Colors are turned on or off for every Printer as per the Options, and
+// restored to how they were when the test finishes.
+func New(t testing.TB, opts *Options) *Recorder {
+	if opts == nil {
+		opts = &Options{}
+	}
+	r := &Recorder{
+		Printer: printer.New(),
+		out:     new(bytes.Buffer),
+		err:     new(bytes.Buffer),
+	}
+	r.Prompter = &Prompter{answers: opts.Answers, out: r.out}
+	r.SetOutWriter(r.out)
+	r.SetErrWriter(r.err)
+	r.SetPagerMode(printer.PagerNever)
+	r.SetPrompter(r.Prompter)
+	width := opts.Width
+	if width <= 0 {
+		width = 80
+	}
+	r.SetWidth(width)
+	noColor := color.NoColor
+	color.NoColor = !opts.Color
+	t.Cleanup(func() { color.NoColor = noColor })
+	return r
+}
+
+// Stdout returns what has been printed to the OutWriter, without colors
+func (r *Recorder) Stdout() string {
+	return formatter.StripAnsi(r.out.String())
+}
+
+// StdoutANSI returns what has been printed to the OutWriter, including any
+// ANSI escape codes
+func (r *Recorder) StdoutANSI() string {
+	return r.out.String()
+}
+
+// Stderr returns what has been printed to the ErrWriter, without colors
+func (r *Recorder) Stderr() string {
+	return formatter.StripAnsi(r.err.String())
+}
+
+// StderrANSI returns what has been printed to the ErrWriter, including any
+// ANSI escape codes
+func (r *Recorder) StderrANSI() string {
+	return r.err.String()
+}
+
+// Reset discards everything that has been recorded
+func (r *Recorder) Reset() {
+	r.out.Reset()
+	r.err.Reset()
+}
+
+// AssertStdout compares what has been printed to the OutWriter, without
+// colors, against the golden file with the passed name
+func (r *Recorder) AssertStdout(t testing.TB, name string) {
+	t.Helper()
+	AssertGolden(t, name, r.Stdout())
+}
+
+// AssertStdoutANSI compares what has been printed to the OutWriter, including
+// any ANSI escape codes, against the golden file with the passed name
+func (r *Recorder) AssertStdoutANSI(t testing.TB, name string) {
+	t.Helper()
+	AssertGolden(t, name, r.StdoutANSI())
+}
+
+// AssertStderr compares what has been printed to the ErrWriter, without
+// colors, against the golden file with the passed name
+func (r *Recorder) AssertStderr(t testing.TB, name string) {
+	t.Helper()
+	AssertGolden(t, name, r.Stderr())
+}
+
+// AssertStderrANSI compares what has been printed to the ErrWriter, including
+// any ANSI escape codes, against the golden file with the passed name
+func (r *Recorder) AssertStderrANSI(t testing.TB, name string) {
+	t.Helper()
+	AssertGolden(t, name, r.StderrANSI())
+}
+
+// AssertGolden compares actual against the golden file with the passed name,
+// "testdata/<name>.golden", and fails the test with a diff if they differ. If
+// the -update flag is set, it writes actual to the golden file
+// instead.
+func AssertGolden(t testing.TB, name, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := writeGolden(path, actual); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if err := compareGolden(path, actual); err != nil {
+		t.Error(err)
+	}
+}
+
+func writeGolden(path, actual string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("Unable to create directory for golden file %v: %v", path, err)
+	}
+	if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+		return fmt.Errorf("Unable to update golden file %v: %v", path, err)
+	}
+	return nil
+}
+
+// compareGolden returns an error with a diff of the golden file at the passed
+// path and actual if they differ
+func compareGolden(path, actual string) error {
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to read golden file %v, run with -update to create it: %v", path, err)
+	}
+	if string(expected) == actual {
+		return nil
+	}
+	diff := (&formatter.Formatter{}).Diff(string(expected), actual, &formatter.DiffOptions{
+		Context: 3,
+		OldName: path,
+		NewName: "actual",
+	})
+	return fmt.Errorf("Output doesn't match golden file %v, run with -update to update it:\n%v", path, strings.Join(diff, "\n"))
+}
+
+// Prompter is a scripted Prompter, which answers each prompt with the next of
+// its answers. Each prompt and its answer are recorded in the Recorder's
+// OutWriter.
+type Prompter struct {
+	answers []string
+	out     *bytes.Buffer
+}
+
+// Select answers a prompt to select an item with the index of the item equal
+// to the next answer. It returns an error if there are no answers left or no
+// item is equal to the answer.
+func (p *Prompter) Select(label string, items []string) (int, error) {
+	if len(p.answers) == 0 {
+		return 0, fmt.Errorf("No answer left for prompt %q", label)
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	for i, item := range items {
+		if item == answer {
+			fmt.Fprintf(p.out, "? %v: %v\n", label, answer)
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("Answer %q isn't an item of prompt %q", answer, label)
+}
+
+// Remaining returns the answers that haven't been given yet
+func (p *Prompter) Remaining() []string {
+	return p.answers
+}
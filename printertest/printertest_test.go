@@ -0,0 +1,81 @@
+package printertest
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/suite"
+	"github.com/tomguerney/printer"
+)
+
+type PrintertestSuite struct {
+	suite.Suite
+}
+
+func (suite *PrintertestSuite) TestRecorder() {
+	r := New(suite.T(), &Options{Width: 40})
+	r.Out("Deploying %v", "web")
+	r.Box("Status", "All systems go", nil)
+	r.Error(errors.New("deploy failed"))
+	suite.Equal(40, r.Width())
+	r.AssertStdout(suite.T(), "recorder_stdout")
+	r.AssertStderr(suite.T(), "recorder_stderr")
+}
+
+func (suite *PrintertestSuite) TestRecorderWithColor() {
+	r := New(suite.T(), &Options{Color: true})
+	r.Out(r.Color("ready", printer.Green))
+	suite.Equal("ready\n", r.Stdout())
+	suite.Equal("\u001b[32mready\u001b[0m\n", r.StdoutANSI())
+	r.AssertStdoutANSI(suite.T(), "recorder_color")
+	r.Reset()
+	suite.Empty(r.StdoutANSI())
+}
+
+func (suite *PrintertestSuite) TestRecorderWithoutColor() {
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+	color.NoColor = false
+	suite.Run("without color", func() {
+		r := New(suite.T(), &Options{})
+		suite.True(color.NoColor)
+		r.Out(r.Color("ready", printer.Green))
+		suite.Equal("ready\n", r.StdoutANSI())
+	})
+	suite.False(color.NoColor)
+}
+
+func (suite *PrintertestSuite) TestPrompter() {
+	r := New(suite.T(), &Options{Answers: []string{"staging", "prod"}})
+	i, err := r.Select("Environment", []string{"dev", "staging"})
+	suite.NoError(err)
+	suite.Equal(1, i)
+	suite.Equal("? Environment: staging\n", r.Stdout())
+	_, err = r.Select("Environment", []string{"dev", "staging"})
+	suite.EqualError(err, `Answer "prod" isn't an item of prompt "Environment"`)
+	_, err = r.Select("Environment", []string{"dev", "staging"})
+	suite.EqualError(err, `No answer left for prompt "Environment"`)
+	suite.Empty(r.Prompter.Remaining())
+}
+
+func (suite *PrintertestSuite) TestCompareGolden() {
+	path := filepath.Join("testdata", "recorder_stderr.golden")
+	suite.NoError(compareGolden(path, "Error: deploy failed\n"))
+	err := compareGolden(path, "Error: something else\n")
+	suite.EqualError(err, strings.Join([]string{
+		"Output doesn't match golden file testdata/recorder_stderr.golden, run with -update to update it:",
+		"--- testdata/recorder_stderr.golden",
+		"+++ actual",
+		"@@ -1,1 +1,1 @@",
+		"-Error: deploy failed",
+		"+Error: something else",
+	}, "\n"))
+	suite.Error(compareGolden(filepath.Join("testdata", "missing.golden"), ""))
+}
+
+func TestPrintertestSuite(t *testing.T) {
+	suite.Run(t, new(PrintertestSuite))
+}
@@ -0,0 +1,195 @@
+package printer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// SlogOptions are the options used to handle slog records. Level is the
+// minimum level of the records that are printed. If it isn't set, debug
+// records are only printed if verbose output is on. Only warnings and errors
+// are printed if output is quiet, whatever the Level.
+//
+// If StencilID is set, each record is rendered with the Template Stencil with
+// that ID instead of as its message followed by its attributes. The data map of
+// the Stencil has the "time", "level" and "message" of the record and its
+// attributes, with the keys of attributes in groups joined by dots, e.g.
+// "request.method".
+type SlogOptions struct {
+	Level     slog.Leveler
+	StencilID string
+}
+
+// SlogHandler is a slog.Handler that prints records with the leveled output
+// methods of a Printer. Records below slog.LevelInfo are printed as per Debug,
+// below slog.LevelWarn as per Info, below slog.LevelError as per Warn, and the
+// rest as per Error, with their attributes aligned as key/value pairs beneath.
+type SlogHandler struct {
+	printer *Printer
+	opts    *SlogOptions
+	values  map[string]string
+	group   string
+}
+
+// NewSlogHandler returns a SlogHandler that prints records as per the passed
+// SlogOptions, e.g. slog.New(printer.NewSlogHandler(nil)). If opts is nil the
+// default SlogOptions are used.
+func NewSlogHandler(opts *SlogOptions) *SlogHandler {
+	return singleton.NewSlogHandler(opts)
+}
+
+// NewSlogHandler returns a SlogHandler that prints records as per the passed
+// SlogOptions, e.g. slog.New(printer.NewSlogHandler(nil)). If opts is nil the
+// default SlogOptions are used.
+func (p *Printer) NewSlogHandler(opts *SlogOptions) *SlogHandler {
+	if opts == nil {
+		opts = &SlogOptions{}
+	}
+	return &SlogHandler{printer: p, opts: opts, values: map[string]string{}}
+}
+
+// Enabled reports whether records of the passed level are printed
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if h.printer.quiet && level < slog.LevelWarn {
+		return false
+	}
+	if h.opts.Level != nil {
+		return level >= h.opts.Level.Level()
+	}
+	if h.printer.verbose {
+		return level >= slog.LevelDebug
+	}
+	return level >= slog.LevelInfo
+}
+
+// Handle prints the passed record. It returns an error if the record can't be
+// rendered with the Template Stencil.
+func (h *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	keys := []string{}
+	values := make(map[string]string, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		keys = h.printer.slogAttr(keys, values, h.group, attr)
+		return true
+	})
+	if h.opts.StencilID != "" {
+		data := make(map[string]string, len(h.values)+len(values)+3)
+		for key, value := range h.values {
+			data[key] = value
+		}
+		for key, value := range values {
+			data[key] = value
+		}
+		data["level"], data["message"] = record.Level.String(), record.Message
+		if !record.Time.IsZero() {
+			data["time"] = h.printer.FormatTime(record.Time, "15:04:05", nil)
+		}
+		result, err := h.printer.stenciller.UseTemplateStencil(h.opts.StencilID, data)
+		if err != nil {
+			return err
+		}
+		h.print(record.Level, result, nil, nil)
+		return nil
+	}
+	h.print(record.Level, record.Message, keys, values)
+	return nil
+}
+
+// WithAttrs returns a SlogHandler that prints through a scoped child Printer
+// tagged with the passed attributes as key=value pairs, as per With. The
+// attributes are also added to the data map of the Template Stencil, if there
+// is one.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	keys := []string{}
+	values := map[string]string{}
+	for _, attr := range attrs {
+		keys = h.printer.slogAttr(keys, values, h.group, attr)
+	}
+	if len(keys) == 0 {
+		return h
+	}
+	child := *h
+	child.values = make(map[string]string, len(h.values)+len(values))
+	for key, value := range h.values {
+		child.values[key] = value
+	}
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		child.values[key] = values[key]
+		pairs[i] = key + "=" + values[key]
+	}
+	child.printer = h.printer.With(strings.Join(pairs, " "), nil)
+	return &child
+}
+
+// WithGroup returns a SlogHandler that prints through a scoped child Printer
+// tagged with the passed group name, as per With, and that qualifies the keys
+// of the attributes of later records with the group name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	child := *h
+	child.printer = h.printer.With(name, nil)
+	child.group = h.group + name + "."
+	return &child
+}
+
+// print prints a message with the leveled output method of the passed level,
+// followed by the passed attributes
+func (h *SlogHandler) print(level slog.Level, message string, keys []string, values map[string]string) {
+	p := h.printer
+	switch {
+	case level < slog.LevelInfo:
+		p.leveled("Debug:", Magenta, message)
+	case level < slog.LevelWarn:
+		p.leveled("Info:", Blue, message)
+	case level < slog.LevelError:
+		p.leveled("Warning:", Yellow, message)
+	default:
+		p.Error(errors.New(message))
+	}
+	for _, line := range p.logFields(keys, values) {
+		fmt.Fprintln(p.ErrWriter, line)
+	}
+}
+
+// slogAttr adds the passed attribute to the passed keys and values with the
+// passed group prefix, flattening groups into dotted keys, and returns the keys
+func (p *Printer) slogAttr(keys []string, values map[string]string, prefix string, attr slog.Attr) []string {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return keys
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, member := range attr.Value.Group() {
+			keys = p.slogAttr(keys, values, prefix, member)
+		}
+		return keys
+	}
+	key := prefix + attr.Key
+	if _, ok := values[key]; !ok {
+		keys = append(keys, key)
+	}
+	values[key] = p.slogValue(attr.Value)
+	return keys
+}
+
+// slogValue formats times and durations with the value formatters of the
+// Printer, and other values as per slog.Value.String
+func (p *Printer) slogValue(value slog.Value) string {
+	switch value.Kind() {
+	case slog.KindTime:
+		return p.FormatTime(value.Time(), time.RFC3339, nil)
+	case slog.KindDuration:
+		return p.FormatDuration(value.Duration())
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,82 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *PrinterSuite) slogOutput(opts *SlogOptions, log func(*slog.Logger)) string {
+	out := new(bytes.Buffer)
+	singleton.ErrWriter = out
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	log(slog.New(NewSlogHandler(opts)))
+	return out.String()
+}
+
+func (suite *PrinterSuite) TestSlogHandler() {
+	output := suite.slogOutput(nil, func(logger *slog.Logger) {
+		logger.Info("request handled",
+			"status", 200,
+			slog.Group("request", "method", "GET", "path", "/health"),
+			"took", 90*time.Second,
+		)
+	})
+	expected := strings.Join([]string{
+		"Info: request handled",
+		"  status:         200",
+		"  request.method: GET",
+		"  request.path:   /health",
+		"  took:           1m30s",
+		"",
+	}, "\n")
+	suite.Equal(expected, output)
+}
+
+func (suite *PrinterSuite) TestSlogHandlerLevels() {
+	output := suite.slogOutput(nil, func(logger *slog.Logger) {
+		logger.Debug("cache miss")
+		logger.Warn("disk almost full")
+		logger.Error("deploy failed")
+	})
+	suite.Equal("Warning: disk almost full\nError: deploy failed\n", output)
+	SetVerbose(true)
+	output = suite.slogOutput(nil, func(logger *slog.Logger) { logger.Debug("cache miss") })
+	suite.Equal("Debug: cache miss\n", output)
+	output = suite.slogOutput(&SlogOptions{Level: slog.LevelWarn}, func(logger *slog.Logger) { logger.Info("deployed") })
+	suite.Equal("", output)
+}
+
+func (suite *PrinterSuite) TestSlogHandlerWithDebugLevel() {
+	output := suite.slogOutput(&SlogOptions{Level: slog.LevelDebug}, func(logger *slog.Logger) { logger.Debug("cache miss") })
+	suite.Equal("Debug: cache miss\n", output)
+	SetQuiet(true)
+	output = suite.slogOutput(&SlogOptions{Level: slog.LevelDebug}, func(logger *slog.Logger) { logger.Info("deployed") })
+	suite.Equal("", output)
+}
+
+func (suite *PrinterSuite) TestSlogHandlerWithAttrsAndGroup() {
+	output := suite.slogOutput(nil, func(logger *slog.Logger) {
+		logger.With("service", "web").WithGroup("db").Info("connected", "host", "localhost")
+	})
+	expected := strings.Join([]string{
+		"[service=web] [db] Info: connected",
+		"[service=web] [db]   db.host: localhost",
+		"",
+	}, "\n")
+	suite.Equal(expected, output)
+}
+
+func (suite *PrinterSuite) TestSlogHandlerWithStencil() {
+	expected := map[string]string{"level": "INFO", "message": "deployed", "service": "web"}
+	suite.Stenciller.On("UseTemplateStencil", "log", expected).Return("deployed web", nil)
+	output := suite.slogOutput(&SlogOptions{StencilID: "log"}, func(logger *slog.Logger) {
+		record := slog.NewRecord(time.Time{}, slog.LevelInfo, "deployed", 0)
+		suite.NoError(logger.With("service", "web").Handler().Handle(context.Background(), record))
+	})
+	suite.Equal("[service=web] Info: deployed web\n", output)
+}
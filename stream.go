@@ -0,0 +1,88 @@
+package printer
+
+import (
+	"errors"
+
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+// StreamOptions are the options used to stream a table. SampleSize is the
+// number of rows measured to size the columns before any rows are printed, and
+// defaults to 20. Fixed column widths set in the Table Stencil's Widths field
+// are used instead of measuring a sample.
+type StreamOptions struct {
+	SampleSize int
+}
+
+// StreamTableStencil takes the ID of a Table Stencil and a channel of "row"
+// maps with string key/values. It returns an error if it can't find a Stencil
+// with the passed ID. It applies the Table Stencil to each row as it is
+// received and prints it, until the channel is closed.
+//
+// Unlike UseTableStencil, StreamTableStencil doesn't need every row in memory
+// to size the columns. Instead it sizes them from the first SampleSize rows,
+// or from the Table Stencil's fixed widths, then prints each later row as it
+// arrives. If a later row doesn't fit, its column grows and the headers are
+// printed again so the columns stay aligned. Footers aren't printed, and
+// streamed output isn't paged.
+func StreamTableStencil(id string, rows <-chan map[string]string, opts *StreamOptions) error {
+	return singleton.StreamTableStencil(id, rows, opts)
+}
+
+// StreamTableStencil takes the ID of a Table Stencil and a channel of "row"
+// maps with string key/values. It returns an error if it can't find a Stencil
+// with the passed ID. It applies the Table Stencil to each row as it is
+// received and prints it, until the channel is closed.
+//
+// Unlike UseTableStencil, StreamTableStencil doesn't need every row in memory
+// to size the columns. Instead it sizes them from the first SampleSize rows,
+// or from the Table Stencil's fixed widths, then prints each later row as it
+// arrives. If a later row doesn't fit, its column grows and the headers are
+// printed again so the columns stay aligned. Footers aren't printed, and
+// streamed output isn't paged.
+func (p *Printer) StreamTableStencil(id string, rows <-chan map[string]string, opts *StreamOptions) error {
+	return p.StreamTableStencilFunc(id, func() (map[string]string, bool) {
+		row, ok := <-rows
+		return row, ok
+	}, opts)
+}
+
+// StreamTableStencilFunc is the same as StreamTableStencil, but takes an
+// iterator function that returns each row in turn, and false once there are no
+// more rows.
+func StreamTableStencilFunc(id string, next func() (map[string]string, bool), opts *StreamOptions) error {
+	return singleton.StreamTableStencilFunc(id, next, opts)
+}
+
+// StreamTableStencilFunc is the same as StreamTableStencil, but takes an
+// iterator function that returns each row in turn, and false once there are no
+// more rows.
+func (p *Printer) StreamTableStencilFunc(id string, next func() (map[string]string, bool), opts *StreamOptions) error {
+	table, err := p.stenciller.TableData(id, nil)
+	if err != nil {
+		return err
+	}
+	fOpts := &formatter.StreamOptions{SampleSize: 20, Widths: table.Widths}
+	if opts != nil && opts.SampleSize > 0 {
+		fOpts.SampleSize = opts.SampleSize
+	}
+	stream := p.formatter.NewTableStream(p.OutWriter, table.Headers, fOpts)
+	for i := 0; ; i++ {
+		row, ok := next()
+		if !ok {
+			break
+		}
+		stencilled, err := p.stenciller.UseTableStencilRow(id, row)
+		if err != nil {
+			var dataErr *DataError
+			if errors.As(err, &dataErr) {
+				dataErr.Row = i
+			}
+			return err
+		}
+		if err := stream.Write(stencilled); err != nil {
+			return err
+		}
+	}
+	return stream.Flush()
+}
@@ -0,0 +1,75 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestStreamTableStencil() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	id := "test id"
+	table := &stenciller.Table{Headers: []string{"NAME"}, Widths: []int{6}}
+	suite.Stenciller.On("TableData", id, []map[string]string(nil)).Return(table, nil)
+	suite.Stenciller.On("UseTableStencilRow", id, map[string]string{"name": "web"}).Return([]string{"web"}, nil)
+	suite.Stenciller.On("UseTableStencilRow", id, map[string]string{"name": "db"}).Return([]string{"db"}, nil)
+	opts := &formatter.StreamOptions{SampleSize: 20, Widths: []int{6}}
+	stream := formatter.New().NewTableStream(out, table.Headers, opts)
+	suite.Formatter.On("NewTableStream", out, table.Headers, opts).Return(stream)
+	rows := make(chan map[string]string, 2)
+	rows <- map[string]string{"name": "web"}
+	rows <- map[string]string{"name": "db"}
+	close(rows)
+	err := StreamTableStencil(id, rows, nil)
+	suite.NoError(err)
+	suite.Equal("NAME\n------\nweb\ndb\n", out.String())
+}
+
+func (suite *PrinterSuite) TestStreamTableStencilFunc() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	id := "test id"
+	table := &stenciller.Table{}
+	suite.Stenciller.On("TableData", id, []map[string]string(nil)).Return(table, nil)
+	suite.Stenciller.On("UseTableStencilRow", id, map[string]string{"n": "1"}).Return([]string{"1"}, nil)
+	opts := &formatter.StreamOptions{SampleSize: 5}
+	suite.Formatter.On("NewTableStream", out, []string(nil), opts).Return(formatter.New().NewTableStream(out, nil, opts))
+	remaining := 3
+	next := func() (map[string]string, bool) {
+		remaining--
+		return map[string]string{"n": "1"}, remaining >= 0
+	}
+	err := StreamTableStencilFunc(id, next, &StreamOptions{SampleSize: 5})
+	suite.NoError(err)
+	suite.Equal("1\n1\n1\n", out.String())
+}
+
+func (suite *PrinterSuite) TestStreamTableStencilWithError() {
+	id := "test id"
+	suite.Stenciller.On("TableData", id, []map[string]string(nil)).Return((*stenciller.Table)(nil), errors.New("error"))
+	err := StreamTableStencil(id, make(chan map[string]string), nil)
+	suite.Error(err)
+	suite.OutWriter.AssertNotCalled(suite.T(), "Write", mock.Anything)
+}
+
+func (suite *PrinterSuite) TestStreamTableStencilWithDataError() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	id := "test id"
+	suite.Stenciller.On("TableData", id, []map[string]string(nil)).Return(&stenciller.Table{}, nil)
+	suite.Stenciller.On("UseTableStencilRow", id, map[string]string{"name": "web"}).Return([]string{"web"}, nil)
+	suite.Stenciller.On("UseTableStencilRow", id, map[string]string{"nmae": "db"}).
+		Return([]string(nil), &DataError{Stencil: id, Key: "nmae"})
+	opts := &formatter.StreamOptions{SampleSize: 20}
+	suite.Formatter.On("NewTableStream", out, []string(nil), opts).Return(formatter.New().NewTableStream(out, nil, opts))
+	rows := make(chan map[string]string, 2)
+	rows <- map[string]string{"name": "web"}
+	rows <- map[string]string{"nmae": "db"}
+	close(rows)
+	err := StreamTableStencil(id, rows, nil)
+	suite.EqualError(err, "Row 1 of table stencil test id has unknown key nmae")
+}
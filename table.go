@@ -0,0 +1,321 @@
+package printer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tomguerney/printer/internal/query"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+// Aggregates
+const (
+	Sum   = stenciller.Sum
+	Avg   = stenciller.Avg
+	Min   = stenciller.Min
+	Max   = stenciller.Max
+	Count = stenciller.Count
+)
+
+// Comparators
+const (
+	StringCompare  = query.StringCompare
+	NumericCompare = query.NumericCompare
+	NaturalCompare = query.NaturalCompare
+	DateCompare    = query.DateCompare
+)
+
+// SortKey is a column to sort table rows by. Comparator is one of
+// StringCompare (the default), NumericCompare, NaturalCompare (which compares
+// runs of digits by their numeric value, so "file2" sorts before "file10") or
+// DateCompare. Values that can't be parsed by the numeric or date comparators
+// sort last, even if Descending is true.
+type SortKey struct {
+	Column     string
+	Descending bool
+	Comparator string
+}
+
+// TableOptions are options applied to the rows of a table before it is
+// rendered. Rows are filtered, then sorted, then grouped.
+//
+// Filters are predicates that a row must satisfy to be kept. Where is a filter
+// expression that a row must also satisfy, made up of comparisons such as
+// `status == running` or `cpu > 50` combined with "&&" and "||". The
+// comparison operators are "==", "!=", ">", ">=", "<", "<=", "~" (matches a
+// regular expression) and "!~". Sort is the columns to sort by in order of
+// precedence. GroupBy is a column to group rows by, with a header line showing
+// the value and row count of each group.
+//
+// Stripe is a style applied to every other row, such as "dim" or "bgblack".
+// Rows satisfying Highlight are styled with HighlightStyle, which defaults to
+// "bold", and rows satisfying Dim are dimmed. Rows satisfying Marker are marked
+// with a "*" in an extra column at the start of the table, such as to mark the
+// current context. Styles can be any of the colors, "bold", "dim", "italic",
+// "underline", "reverse", or a background color such as "bgblue".
+type TableOptions struct {
+	Filters        []func(row map[string]string) bool
+	Where          string
+	Sort           []*SortKey
+	GroupBy        string
+	Stripe         string
+	Highlight      func(row map[string]string) bool
+	HighlightStyle string
+	Dim            func(row map[string]string) bool
+	Marker         func(row map[string]string) bool
+}
+
+// rowStyle is the row styling of a table, merged from its TableOptions
+type rowStyle struct {
+	stripe         string
+	highlight      func(row map[string]string) bool
+	highlightStyle string
+	dim            func(row map[string]string) bool
+	marker         func(row map[string]string) bool
+}
+
+// tabulatedTable is a table tabulated into lines, split into the lines of its
+// headers, of each of its rows and of its footer. The lines of a row are
+// styled, and preceded by the header of the row's group if it starts one.
+type tabulatedTable struct {
+	header []string
+	rows   [][]string
+	footer []string
+}
+
+// lines returns the lines of the headers, of the rows from start to end and of
+// the footer
+func (t *tabulatedTable) lines(start, end int) []string {
+	lines := append([]string{}, t.header...)
+	for _, row := range t.rows[start:end] {
+		lines = append(lines, row...)
+	}
+	return append(lines, t.footer...)
+}
+
+// tabulateTable applies a Table Stencil to the passed rows, which are in the
+// order of the passed groups if they are grouped, and tabulates and styles the
+// result. Column widths and the footer are those of every row, so any range of
+// rows can be printed with the same layout.
+func (p *Printer) tabulateTable(id string, rows []map[string]string, groups []*query.Group, style *rowStyle) (*tabulatedTable, error) {
+	stencilled, err := p.stenciller.StencilTable(id, rows)
+	if err != nil {
+		return nil, err
+	}
+	result, headerRows := stencilled.Rows, stencilled.HeaderRows
+	if style.marker != nil {
+		result = addMarkers(result, headerRows, rows, style.marker)
+	}
+	rowLines := splitLines(p.formatter.Tabulate(result), result)
+	headers := groupHeaders(groups)
+	table := &tabulatedTable{}
+	for i, rowLine := range rowLines {
+		index := i - headerRows
+		if index < 0 {
+			table.header = append(table.header, rowLine...)
+			continue
+		}
+		if index >= len(rows) {
+			table.footer = append(table.footer, rowLine...)
+			continue
+		}
+		lines := []string{}
+		if header, ok := headers[index]; ok {
+			lines = append(lines, header)
+		}
+		for _, line := range rowLine {
+			for _, s := range style.styles(index, rows[index]) {
+				line = p.formatter.Style(line, s)
+			}
+			lines = append(lines, line)
+		}
+		table.rows = append(table.rows, lines)
+	}
+	return table, nil
+}
+
+// rowStyle returns the row styling of a table with the passed TableOptions,
+// striping rows if the table style is TableStriped
+func (p *Printer) rowStyle(opts []*TableOptions) *rowStyle {
+	style := rowStyleFrom(opts)
+	if style.stripe == "" && p.tableStyle == TableStriped {
+		style.stripe = "dim"
+	}
+	return style
+}
+
+func rowStyleFrom(opts []*TableOptions) *rowStyle {
+	style := &rowStyle{highlightStyle: "bold"}
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+		if o.Stripe != "" {
+			style.stripe = o.Stripe
+		}
+		if o.Highlight != nil {
+			style.highlight = o.Highlight
+		}
+		if o.HighlightStyle != "" {
+			style.highlightStyle = o.HighlightStyle
+		}
+		if o.Dim != nil {
+			style.dim = o.Dim
+		}
+		if o.Marker != nil {
+			style.marker = o.Marker
+		}
+	}
+	return style
+}
+
+func (s *rowStyle) empty() bool {
+	return s.stripe == "" && s.highlight == nil && s.dim == nil && s.marker == nil
+}
+
+// styles returns the styles to apply to the passed row, the index-th row of
+// the table
+func (s *rowStyle) styles(index int, row map[string]string) []string {
+	styles := []string{}
+	if s.stripe != "" && index%2 == 1 {
+		styles = append(styles, s.stripe)
+	}
+	if s.highlight != nil && s.highlight(row) {
+		styles = append(styles, s.highlightStyle)
+	}
+	if s.dim != nil && s.dim(row) {
+		styles = append(styles, "dim")
+	}
+	return styles
+}
+
+// addMarkers prepends the marker column to the stencilled rows, which start
+// with headerRows rows of table headers and end with any footer rows
+func addMarkers(stencilled [][]string, headerRows int, rows []map[string]string, marker func(map[string]string) bool) [][]string {
+	marked := make([][]string, len(stencilled))
+	for i, row := range stencilled {
+		cell := ""
+		if i >= headerRows && i < headerRows+len(rows) && marker(rows[i-headerRows]) {
+			cell = "*"
+		}
+		marked[i] = append([]string{cell}, row...)
+	}
+	return marked
+}
+
+// splitLines splits tabulated lines into the lines of each of the stencilled
+// rows they were tabulated from, since a row with multi-line cells spans more
+// than one line
+func splitLines(lines []string, stencilled [][]string) [][]string {
+	split := make([][]string, len(stencilled))
+	start := 0
+	for i, row := range stencilled {
+		height := 1
+		for _, cell := range row {
+			if h := strings.Count(cell, "\n") + 1; h > height {
+				height = h
+			}
+		}
+		split[i] = lines[start : start+height]
+		start += height
+	}
+	return split
+}
+
+// prepareRows applies the passed TableOptions to a copy of the passed rows. It
+// returns the index in the passed rows of each of the prepared rows, and if the
+// rows are grouped, the groups, with the rows in group order.
+func prepareRows(rows []map[string]string, opts []*TableOptions) ([]map[string]string, []int, []*query.Group, error) {
+	prepared := append([]map[string]string(nil), rows...)
+	origins := make([]int, len(rows))
+	for i := range origins {
+		origins[i] = i
+	}
+	groupBy := ""
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+		predicates := []query.Predicate{}
+		for _, filter := range o.Filters {
+			predicates = append(predicates, filter)
+		}
+		if o.Where != "" {
+			predicate, err := query.Parse(o.Where)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			predicates = append(predicates, predicate)
+		}
+		if len(predicates) > 0 {
+			prepared, origins = pick(prepared, origins, query.Select(prepared, predicates...))
+		}
+		keys := make([]*query.SortKey, len(o.Sort))
+		for i, key := range o.Sort {
+			keys[i] = &query.SortKey{
+				Column:     key.Column,
+				Descending: key.Descending,
+				Comparator: key.Comparator,
+			}
+		}
+		order, err := query.Order(prepared, keys)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		prepared, origins = pick(prepared, origins, order)
+		if o.GroupBy != "" {
+			groupBy = o.GroupBy
+		}
+	}
+	if groupBy == "" {
+		return prepared, origins, nil, nil
+	}
+	groups := query.GroupBy(prepared, groupBy)
+	members := map[string][]int{}
+	for i, row := range prepared {
+		members[row[groupBy]] = append(members[row[groupBy]], i)
+	}
+	order := []int{}
+	for _, group := range groups {
+		order = append(order, members[group.Value]...)
+	}
+	grouped, origins := pick(prepared, origins, order)
+	return grouped, origins, groups, nil
+}
+
+// pick returns the rows at the passed indices and their origins
+func pick(rows []map[string]string, origins []int, indices []int) ([]map[string]string, []int) {
+	picked := make([]map[string]string, len(indices))
+	pickedOrigins := make([]int, len(indices))
+	for i, index := range indices {
+		picked[i], pickedOrigins[i] = rows[index], origins[index]
+	}
+	return picked, pickedOrigins
+}
+
+// originalRow sets the row of a DataError to the index of the row in the rows
+// it was prepared from, whose origins are passed
+func originalRow(err error, origins []int) error {
+	var dataErr *DataError
+	if errors.As(err, &dataErr) && dataErr.Row >= 0 && dataErr.Row < len(origins) {
+		dataErr.Row = origins[dataErr.Row]
+	}
+	return err
+}
+
+// groupHeaders returns the header line of each group, keyed by the index of the
+// group's first row
+func groupHeaders(groups []*query.Group) map[int]string {
+	headers := map[int]string{}
+	start := 0
+	for _, group := range groups {
+		value := group.Value
+		if value == "" {
+			value = "(empty)"
+		}
+		headers[start] = fmt.Sprintf("%v (%d)", value, len(group.Rows))
+		start += len(group.Rows)
+	}
+	return headers
+}
@@ -0,0 +1,218 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/humanize"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestTableStencilWithOptions() {
+	id := "test id"
+	rows := []map[string]string{
+		{"name": "web", "status": "running", "cpu": "5"},
+		{"name": "db", "status": "stopped", "cpu": "40"},
+		{"name": "cache", "status": "running", "cpu": "12"},
+	}
+	expected := []map[string]string{rows[2], rows[0]}
+	suite.Stenciller.On("UseTableStencil", id, expected).Return([][]string{{"cache"}, {"web"}}, nil)
+	suite.Formatter.On("Tabulate", [][]string{{"cache"}, {"web"}}, mock.Anything).Return([]string{"cache", "web"})
+	err := UseTableStencil(id, rows, &TableOptions{
+		Where: "status == running",
+		Sort:  []*SortKey{{Column: "cpu", Comparator: NumericCompare, Descending: true}},
+	})
+	suite.NoError(err)
+	suite.Equal("web", rows[0]["name"])
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("cache"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("web"))
+}
+
+func (suite *PrinterSuite) TestTableStencilWithFilters() {
+	id := "test id"
+	rows := []map[string]string{{"name": "web"}, {"name": "db"}}
+	notWeb := func(row map[string]string) bool { return row["name"] != "web" }
+	suite.Stenciller.On("UseTableStencil", id, rows[1:]).Return([][]string{{"db"}}, nil)
+	suite.Formatter.On("Tabulate", mock.Anything, mock.Anything).Return([]string{"db"})
+	err := UseTableStencil(id, rows, &TableOptions{Filters: []func(map[string]string) bool{notWeb}})
+	suite.NoError(err)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("db"))
+}
+
+func (suite *PrinterSuite) TestTableStencilWithGroups() {
+	id := "test id"
+	rows := []map[string]string{
+		{"name": "web", "status": "running"},
+		{"name": "db", "status": "stopped"},
+		{"name": "cache", "status": "running"},
+	}
+	grouped := []map[string]string{rows[0], rows[2], rows[1]}
+	stencilled := [][]string{{"NAME"}, {"----"}, {"web"}, {"cache"}, {"db"}}
+	suite.Stenciller.On("StencilTable", id, grouped).Return(&stenciller.StencilledTable{Rows: stencilled, HeaderRows: 2}, nil)
+	suite.Formatter.On("Tabulate", stencilled, mock.Anything).Return([]string{"NAME", "----", "web", "cache", "db"})
+	err := UseTableStencil(id, rows, &TableOptions{GroupBy: "status"})
+	suite.NoError(err)
+	expected := []string{"NAME", "----", "running (2)", "web", "cache", "stopped (1)", "db"}
+	for _, line := range expected {
+		suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln(line))
+	}
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", len(expected))
+}
+
+func (suite *PrinterSuite) TestTableStencilWithGroupsAndMultiLineCells() {
+	id := "test id"
+	rows := []map[string]string{{"name": "web", "status": "running"}}
+	stencilled := [][]string{{"web\nserver"}}
+	suite.Stenciller.On("StencilTable", id, rows).Return(&stenciller.StencilledTable{Rows: stencilled}, nil)
+	suite.Formatter.On("Tabulate", stencilled, mock.Anything).Return([]string{"web", "server"})
+	err := UseTableStencil(id, rows, &TableOptions{GroupBy: "status"})
+	suite.NoError(err)
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("running (1)"))
+	suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln("server"))
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", 3)
+}
+
+func (suite *PrinterSuite) TestTableStencilWithRowStyles() {
+	id := "test id"
+	rows := []map[string]string{
+		{"name": "web", "enabled": "true"},
+		{"name": "db", "enabled": "false"},
+		{"name": "cache", "enabled": "true"},
+	}
+	stencilled := [][]string{{"NAME"}, {"----"}, {"web"}, {"db"}, {"cache"}, {"-----"}, {"3"}}
+	marked := [][]string{{"", "NAME"}, {"", "----"}, {"*", "web"}, {"", "db"}, {"", "cache"}, {"", "-----"}, {"", "3"}}
+	suite.Stenciller.On("StencilTable", id, rows).Return(&stenciller.StencilledTable{Rows: stencilled, HeaderRows: 2, FooterRows: 2}, nil)
+	suite.Formatter.On("Tabulate", marked, mock.Anything).Return([]string{"NAME", "----", "* web", "db", "cache", "-----", "3"})
+	suite.Formatter.On("Style", "* web", "bold").Return("bold web")
+	suite.Formatter.On("Style", "db", "bgblack").Return("striped db")
+	suite.Formatter.On("Style", "striped db", "dim").Return("dim db")
+	err := UseTableStencil(id, rows, &TableOptions{
+		Stripe:    "bgblack",
+		Highlight: func(row map[string]string) bool { return row["name"] == "web" },
+		Dim:       func(row map[string]string) bool { return row["enabled"] == "false" },
+		Marker:    func(row map[string]string) bool { return row["name"] == "web" },
+	})
+	suite.NoError(err)
+	expected := []string{"NAME", "----", "bold web", "dim db", "cache", "-----", "3"}
+	for _, line := range expected {
+		suite.OutWriter.AssertCalled(suite.T(), "Write", fmt.Sprintln(line))
+	}
+	suite.OutWriter.AssertNumberOfCalls(suite.T(), "Write", len(expected))
+}
+
+func (suite *PrinterSuite) TestTableStencilWithOptionsAndDataError() {
+	id := "test id"
+	rows := []map[string]string{
+		{"name": "web", "status": "running", "cpu": "5"},
+		{"name": "db", "status": "stopped", "cpu": "40"},
+		{"nmae": "cache", "status": "running", "cpu": "12"},
+	}
+	expected := []map[string]string{rows[2], rows[0]}
+	suite.Stenciller.On("UseTableStencil", id, expected).Return([][]string(nil), &DataError{Stencil: id, Key: "nmae"})
+	err := UseTableStencil(id, rows, &TableOptions{
+		Where: "status == running",
+		Sort:  []*SortKey{{Column: "cpu", Comparator: NumericCompare, Descending: true}},
+	})
+	suite.EqualError(err, "Row 2 of table stencil test id has unknown key nmae")
+}
+
+func (suite *PrinterSuite) TestTableStencilWithGroupsAndDataError() {
+	id := "test id"
+	rows := []map[string]string{
+		{"name": "web", "status": "running"},
+		{"name": "db", "status": "stopped"},
+		{"name": "cache", "status": "running"},
+	}
+	grouped := []map[string]string{rows[0], rows[2], rows[1]}
+	suite.Stenciller.On("StencilTable", id, grouped).Return(nil, &DataError{Stencil: id, Row: 2, Key: "status"})
+	err := UseTableStencil(id, rows, &TableOptions{GroupBy: "status"})
+	suite.EqualError(err, "Row 1 of table stencil test id has unknown key status")
+}
+
+func (suite *PrinterSuite) TestTableStencilWithGroupsFooterAndMultiLineCells() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	id := "test id"
+	rows := []map[string]string{
+		{"name": "web", "status": "running"},
+		{"name": "db", "status": "stopped"},
+	}
+	stencilled := [][]string{{"NAME"}, {"----"}, {"web\nserver"}, {"db"}, {"-----"}, {"2"}}
+	suite.Stenciller.On("StencilTable", id, rows).Return(&stenciller.StencilledTable{Rows: stencilled, HeaderRows: 2, FooterRows: 2}, nil)
+	suite.Formatter.On("Tabulate", stencilled, mock.Anything).Return([]string{"NAME", "----", "web", "server", "db", "-----", "2"})
+	err := UseTableStencil(id, rows, &TableOptions{GroupBy: "status"})
+	suite.NoError(err)
+	suite.Equal("NAME\n----\nrunning (1)\nweb\nserver\nstopped (1)\ndb\n-----\n2\n", out.String())
+}
+
+func (suite *PrinterSuite) TestTableStencilWithInvalidWhere() {
+	err := UseTableStencil("test id", nil, &TableOptions{Where: "status =="})
+	suite.Error(err)
+	suite.Stenciller.AssertNotCalled(suite.T(), "UseTableStencil", mock.Anything, mock.Anything)
+}
+
+func (suite *PrinterSuite) TestAddTableStencilWithFooter() {
+	stencil := &TableStencil{
+		ID:          "test id",
+		ColumnOrder: []string{"name", "cost"},
+		Footer:      map[string]string{"cost": Sum},
+		FooterLabel: "Total",
+	}
+	suite.Stenciller.On("AddTableStencil", mock.MatchedBy(func(s *stenciller.TableStencil) bool {
+		return s.ID == stencil.ID && s.Footer["cost"] == Sum && s.FooterLabel == "Total"
+	})).Return(nil)
+	err := AddTableStencil(stencil)
+	suite.NoError(err)
+}
+
+func (suite *PrinterSuite) TestAddTableStencilWithDataPolicies() {
+	stencil := &TableStencil{
+		ID:          "test id",
+		ColumnOrder: []string{"name", "zone"},
+		Defaults:    map[string]string{"zone": "-"},
+		UnknownKeys: PolicyWarn,
+		MissingKeys: PolicyError,
+	}
+	suite.Stenciller.On("AddTableStencil", mock.MatchedBy(func(s *stenciller.TableStencil) bool {
+		return s.Defaults["zone"] == "-" && s.UnknownKeys == PolicyWarn && s.MissingKeys == PolicyError
+	})).Return(nil)
+	suite.NoError(AddTableStencil(stencil))
+}
+
+func (suite *PrinterSuite) TestAddTableStencilWithExtends() {
+	stencil := &TableStencil{
+		ID:            "test id",
+		Extends:       "base",
+		RemoveColumns: []string{"cpu"},
+	}
+	suite.Stenciller.On("AddTableStencil", mock.MatchedBy(func(s *stenciller.TableStencil) bool {
+		return s.Extends == "base" && len(s.RemoveColumns) == 1 && s.RemoveColumns[0] == "cpu"
+	})).Return(nil)
+	suite.NoError(AddTableStencil(stencil))
+}
+
+func (suite *PrinterSuite) TestResolvedTableStencil() {
+	suite.Stenciller.On("ResolveTableStencil", "test id").Return(&stenciller.TableStencil{
+		ID:          "test id",
+		ColumnOrder: []string{"name", "size"},
+		Formats:     map[string]*humanize.Format{"size": {Type: humanize.Bytes, SI: true}},
+	}, nil)
+	actual, err := ResolvedTableStencil("test id")
+	suite.NoError(err)
+	suite.Equal(&TableStencil{
+		ID:          "test id",
+		ColumnOrder: []string{"name", "size"},
+		Formats:     map[string]*ValueFormat{"size": {Type: BytesFormat, SI: true}},
+	}, actual)
+	suite.Stenciller.On("ResolveTableStencil", "missing").Return((*stenciller.TableStencil)(nil), errors.New("error"))
+	_, err = ResolvedTableStencil("missing")
+	suite.Error(err)
+}
+
+func (suite *PrinterSuite) TestAddPartial() {
+	suite.Stenciller.On("AddPartial", "header", "{{ .name }}").Return(nil)
+	suite.NoError(AddPartial("header", "{{ .name }}"))
+	suite.Stenciller.AssertCalled(suite.T(), "AddPartial", "header", "{{ .name }}")
+}
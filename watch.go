@@ -0,0 +1,155 @@
+package printer
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+// WatchOptions are the options for watching a directory of Stencils. Interval
+// is how often the directory is polled for changes, one second by default.
+// OnReload is called with the path of each file that is loaded, reloaded or
+// removed, and the error if it couldn't be loaded. If OnReload is nil, errors
+// are printed with Error and reloads with Debug.
+type WatchOptions struct {
+	Interval time.Duration
+	OnReload func(path string, err error)
+}
+
+// StencilWatcher polls a directory of Stencils and reloads the files that
+// change until it's stopped
+type StencilWatcher struct {
+	printer  *Printer
+	dir      string
+	opts     *WatchOptions
+	files    map[string]fileState
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// fileState is the state of a file when it was last polled
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// WatchStencilDir loads the Stencils of every JSON file in the passed
+// directory as per LoadStencilFile, then polls the directory in the background
+// until the returned StencilWatcher is stopped. Files that are added or change
+// are reloaded, and the Stencils of files that are removed are removed. A file
+// that can't be loaded keeps the Stencils last loaded from it, so a broken edit
+// doesn't break output. It returns an error if the directory can't be read, and
+// otherwise reports each load as per the WatchOptions. Polling works on every
+// platform and file system, which makes it suitable for previewing edits to
+// Stencils without restarting a long-running command.
+func WatchStencilDir(dir string, opts *WatchOptions) (*StencilWatcher, error) {
+	return singleton.WatchStencilDir(dir, opts)
+}
+
+// WatchStencilDir loads the Stencils of every JSON file in the passed
+// directory as per LoadStencilFile, then polls the directory in the background
+// until the returned StencilWatcher is stopped. Files that are added or change
+// are reloaded, and the Stencils of files that are removed are removed. A file
+// that can't be loaded keeps the Stencils last loaded from it, so a broken edit
+// doesn't break output. It returns an error if the directory can't be read, and
+// otherwise reports each load as per the WatchOptions. Polling works on every
+// platform and file system, which makes it suitable for previewing edits to
+// Stencils without restarting a long-running command.
+func (p *Printer) WatchStencilDir(dir string, opts *WatchOptions) (*StencilWatcher, error) {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+	w := &StencilWatcher{
+		printer: p,
+		dir:     dir,
+		opts:    opts,
+		files:   map[string]fileState{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if err := w.poll(); err != nil {
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+// Stop stops polling and waits for any reload in progress to finish
+func (w *StencilWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+}
+
+func (w *StencilWatcher) run() {
+	defer close(w.done)
+	interval := w.opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.poll(); err != nil {
+				w.report(w.dir, err)
+			}
+		}
+	}
+}
+
+// poll loads each file of the directory that is new or has changed since it
+// was last polled, and removes the Stencils of each file that has been removed
+func (w *StencilWatcher) poll() error {
+	paths, err := stencilFiles(w.dir)
+	if err != nil {
+		return err
+	}
+	polled := map[string]bool{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		polled[path] = true
+		state := fileState{modTime: info.ModTime(), size: info.Size()}
+		if last, ok := w.files[path]; ok && last.modTime.Equal(state.modTime) && last.size == state.size {
+			continue
+		}
+		w.files[path] = state
+		w.report(path, w.printer.LoadStencilFile(path))
+	}
+	removed := []string{}
+	for path := range w.files {
+		if !polled[path] {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(removed)
+	for _, path := range removed {
+		delete(w.files, path)
+		w.report(path, w.printer.stenciller.Load(path, &stenciller.Set{}))
+	}
+	return nil
+}
+
+// report reports the load of a file as per the WatchOptions
+func (w *StencilWatcher) report(path string, err error) {
+	if w.opts.OnReload != nil {
+		w.opts.OnReload(path, err)
+		return
+	}
+	if err != nil {
+		w.printer.Error(err)
+		return
+	}
+	w.printer.Debug("Loaded stencils from %v", path)
+}
@@ -0,0 +1,54 @@
+package printer
+
+import (
+	"os"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+func (suite *PrinterSuite) TestWatchStencilDir() {
+	dir := suite.T().TempDir()
+	path := suite.writeStencilFile(dir, "pods.json", `{"templates": [{"id": "status", "template": "{{ .status }}"}]}`)
+	reloads := map[string][]error{}
+	suite.Stenciller.On("Load", path, mock.Anything).Return(nil)
+	w, err := WatchStencilDir(dir, &WatchOptions{
+		Interval: time.Hour,
+		OnReload: func(path string, err error) { reloads[path] = append(reloads[path], err) },
+	})
+	suite.NoError(err)
+	defer w.Stop()
+	suite.Equal(map[string][]error{path: {nil}}, reloads)
+
+	suite.NoError(w.poll())
+	suite.Stenciller.AssertNumberOfCalls(suite.T(), "Load", 1)
+
+	suite.writeStencilFile(dir, "pods.json", `{"templates": [`)
+	later := time.Now().Add(time.Minute)
+	suite.NoError(os.Chtimes(path, later, later))
+	suite.NoError(w.poll())
+	suite.Len(reloads[path], 2)
+	suite.EqualError(reloads[path][1], "Unable to load stencils from "+path+": unexpected EOF")
+	suite.Stenciller.AssertNumberOfCalls(suite.T(), "Load", 1)
+
+	suite.NoError(os.Remove(path))
+	suite.NoError(w.poll())
+	suite.Stenciller.AssertCalled(suite.T(), "Load", path, &stenciller.Set{})
+	suite.Len(reloads[path], 3)
+	suite.NoError(reloads[path][2])
+}
+
+func (suite *PrinterSuite) TestWatchStencilDirReportsErrors() {
+	dir := suite.T().TempDir()
+	path := suite.writeStencilFile(dir, "broken.json", `{`)
+	suite.Stenciller.On("Color", mock.Anything, mock.Anything).Return("", false)
+	w, err := WatchStencilDir(dir, &WatchOptions{Interval: time.Millisecond})
+	suite.NoError(err)
+	w.Stop()
+	w.Stop()
+	suite.ErrWriter.AssertCalled(suite.T(), "Write", "Error: Unable to load stencils from "+path+"\n")
+	suite.ErrWriter.AssertCalled(suite.T(), "Write", "  caused by: unexpected EOF\n")
+	_, err = WatchStencilDir(dir+"/missing", nil)
+	suite.Error(err)
+}
@@ -0,0 +1,57 @@
+package printer
+
+import (
+	"github.com/tomguerney/printer/internal/formatter"
+	"github.com/tomguerney/printer/internal/stenciller"
+)
+
+// WithOptions are the options used to create a scoped child Printer. Color is
+// the color of the prefix tag.
+type WithOptions struct {
+	Color string
+}
+
+// With returns a scoped child Printer that prefixes every line it prints with
+// the passed prefix as a tag, e.g. "[build-3] ". If opts is nil the tag is not
+// colored.
+//
+// The child shares the writers and stencils of the Printer and inherits its
+// options. Options set on the child, and stencils added to it, don't affect
+// the Printer.
+func With(prefix string, opts *WithOptions) *Printer {
+	return singleton.With(prefix, opts)
+}
+
+// With returns a scoped child Printer that prefixes every line it prints with
+// the passed prefix as a tag, e.g. "[build-3] ". If opts is nil the tag is not
+// colored.
+//
+// The child shares the writers and stencils of the Printer and inherits its
+// options. Options set on the child, and stencils added to it, don't affect
+// the Printer.
+func (p *Printer) With(prefix string, opts *WithOptions) *Printer {
+	tag := "[" + prefix + "] "
+	width := formatter.Width(tag)
+	if opts != nil && opts.Color != "" {
+		tag = p.Color(tag[:len(tag)-1], opts.Color) + " "
+	}
+	return p.child(tag, width)
+}
+
+// child returns a copy of the Printer that writes through to the Printer's
+// writers with the passed prefix at the start of every line, with its width
+// reduced by the width of the prefix
+func (p *Printer) child(prefix string, prefixWidth int) *Printer {
+	child := *p
+	child.OutWriter = newPrefixWriter(p.OutWriter, prefix)
+	child.ErrWriter = newPrefixWriter(p.ErrWriter, prefix)
+	child.width = p.Width() - prefixWidth
+	if f, ok := p.formatter.(*formatter.Formatter); ok {
+		fCopy := *f
+		child.formatter = &fCopy
+	}
+	if s, ok := p.stenciller.(*stenciller.Stenciller); ok {
+		child.stenciller = s.Child()
+	}
+	return &child
+}
@@ -0,0 +1,59 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *PrinterSuite) TestWith() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	suite.Formatter.On("Text", "line1\nline2", mock.Anything).Return("line1\nline2\n")
+	child := With("build-3", nil)
+	child.Out("line1\nline2")
+	suite.Equal("[build-3] line1\n[build-3] line2\n", out.String())
+	suite.Equal(70, child.Width())
+}
+
+func (suite *PrinterSuite) TestWithWideRunes() {
+	suite.Equal(73, With("構築", nil).Width())
+	suite.Equal(75, With("🎉", nil).Width())
+}
+
+func (suite *PrinterSuite) TestWithColor() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	suite.Stenciller.On("Color", "[job]", Cyan).Return("cyan[job]", true)
+	suite.Formatter.On("Text", "message", mock.Anything).Return("message\n")
+	With("job", &WithOptions{Color: Cyan}).Out("message")
+	suite.Equal("cyan[job] message\n", out.String())
+}
+
+func (suite *PrinterSuite) TestWithPrefixesErr() {
+	errOut := new(bytes.Buffer)
+	singleton.ErrWriter = errOut
+	suite.Formatter.On("Text", "failed", mock.Anything).Return("failed\n")
+	With("job", nil).Err("failed")
+	suite.Equal("[job] failed\n", errOut.String())
+}
+
+func (suite *PrinterSuite) TestWithPrefixesWritesAcrossLines() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	child := With("job", nil)
+	fmt.Fprint(child.OutWriter, "partial ")
+	fmt.Fprint(child.OutWriter, "line\nnext")
+	suite.Equal("[job] partial line\n[job] next", out.String())
+}
+
+func (suite *PrinterSuite) TestWithDoesNotLeakToParent() {
+	child := With("job", nil)
+	child.SetWidth(20)
+	child.SetAutoWrap(true)
+	child.SetOutWriter(new(bytes.Buffer))
+	suite.Equal(80, singleton.Width())
+	suite.False(singleton.autoWrap)
+	suite.Equal(suite.OutWriter, singleton.OutWriter)
+}
@@ -0,0 +1,78 @@
+package printer
+
+import (
+	"strings"
+
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+// WrapOptions are the options used to wrap text. Width is the number of
+// columns lines are wrapped at and defaults to the width of the Printer.
+// Indent is the number of spaces the first line is indented by, and Hanging is
+// the number of spaces every subsequent line is indented by.
+type WrapOptions struct {
+	Width   int
+	Indent  int
+	Hanging int
+}
+
+// Wrap returns the passed text wrapped at word boundaries so that no line is
+// wider than the width set in opts, or the width of the Printer if opts is nil.
+// ANSI color spans are kept intact across line breaks and wide runes are
+// counted as two columns.
+func Wrap(text string, opts *WrapOptions) string {
+	return singleton.Wrap(text, opts)
+}
+
+// Wrap returns the passed text wrapped at word boundaries so that no line is
+// wider than the width set in opts, or the width of the Printer if opts is nil.
+// ANSI color spans are kept intact across line breaks and wide runes are
+// counted as two columns.
+func (p *Printer) Wrap(text string, opts *WrapOptions) string {
+	fOpts := &formatter.WrapOptions{Width: p.Width()}
+	if opts != nil {
+		if opts.Width > 0 {
+			fOpts.Width = opts.Width
+		}
+		fOpts.Indent = opts.Indent
+		fOpts.Hanging = opts.Hanging
+	}
+	return strings.Join(p.formatter.Wrap(text, fOpts), "\n")
+}
+
+// SetAutoWrap sets whether text printed with Out and Err is wrapped at the
+// width of the Printer
+func SetAutoWrap(autoWrap bool) {
+	singleton.SetAutoWrap(autoWrap)
+}
+
+// SetAutoWrap sets whether text printed with Out and Err is wrapped at the
+// width of the Printer
+func (p *Printer) SetAutoWrap(autoWrap bool) {
+	p.autoWrap = autoWrap
+}
+
+// Indent returns a child Printer that prefixes every line it prints with n
+// spaces. The child shares the writers, stencils and options of the Printer,
+// and its width is reduced by n. A negative n is treated as 0.
+func Indent(n int) *Printer {
+	return singleton.Indent(n)
+}
+
+// Indent returns a child Printer that prefixes every line it prints with n
+// spaces. The child shares the writers, stencils and options of the Printer,
+// and its width is reduced by n. A negative n is treated as 0.
+func (p *Printer) Indent(n int) *Printer {
+	n = max(0, n)
+	return p.child(strings.Repeat(" ", n), n)
+}
+
+func (p *Printer) text(i interface{}, a ...interface{}) string {
+	text := p.formatter.Text(i, a...)
+	if !p.autoWrap {
+		return text
+	}
+	trimmed := strings.TrimSuffix(text, "\n")
+	wrapped := p.formatter.Wrap(trimmed, &formatter.WrapOptions{Width: p.Width()})
+	return strings.Join(wrapped, "\n") + text[len(trimmed):]
+}
@@ -0,0 +1,60 @@
+package printer
+
+import (
+	"bytes"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/tomguerney/printer/internal/formatter"
+)
+
+func (suite *PrinterSuite) TestWrap() {
+	opts := &formatter.WrapOptions{Width: 20, Indent: 2, Hanging: 4}
+	suite.Formatter.On("Wrap", "some text", opts).Return([]string{"  some", "    text"})
+	actual := Wrap("some text", &WrapOptions{Width: 20, Indent: 2, Hanging: 4})
+	suite.Equal("  some\n    text", actual)
+}
+
+func (suite *PrinterSuite) TestWrapWithPrinterWidth() {
+	suite.Formatter.On("Wrap", "some text", &formatter.WrapOptions{Width: 80}).Return([]string{"some text"})
+	actual := Wrap("some text", nil)
+	suite.Equal("some text", actual)
+}
+
+func (suite *PrinterSuite) TestOutWithAutoWrap() {
+	SetAutoWrap(true)
+	suite.Formatter.On("Text", "long text", mock.Anything).Return("long text\n")
+	suite.Formatter.On("Wrap", "long text", &formatter.WrapOptions{Width: 80}).Return([]string{"long", "text"})
+	Out("long text")
+	suite.OutWriter.AssertCalled(suite.T(), "Write", "long\ntext\n")
+}
+
+func (suite *PrinterSuite) TestErrWithAutoWrap() {
+	SetAutoWrap(true)
+	suite.Formatter.On("Text", "long error", mock.Anything).Return("long error\n")
+	suite.Formatter.On("Wrap", "long error", &formatter.WrapOptions{Width: 80}).Return([]string{"long", "error"})
+	Err("long error")
+	suite.ErrWriter.AssertCalled(suite.T(), "Write", "long\nerror\n")
+}
+
+func (suite *PrinterSuite) TestIndent() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	suite.Formatter.On("Text", "line1\nline2", mock.Anything).Return("line1\nline2\n")
+	suite.Formatter.On("Text", "nested", mock.Anything).Return("nested\n")
+	child := Indent(2)
+	child.Out("line1\nline2")
+	child.Indent(2).Out("nested")
+	suite.Equal("  line1\n  line2\n    nested\n", out.String())
+	suite.Equal(78, child.Width())
+	suite.Equal(80, Width())
+}
+
+func (suite *PrinterSuite) TestIndentWithNegativeCount() {
+	out := new(bytes.Buffer)
+	singleton.OutWriter = out
+	suite.Formatter.On("Text", "x", mock.Anything).Return("x\n")
+	child := Indent(-2)
+	child.Out("x")
+	suite.Equal("x\n", out.String())
+	suite.Equal(80, child.Width())
+}
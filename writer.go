@@ -0,0 +1,42 @@
+package printer
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// prefixWriter is an io.Writer that writes a prefix at the start of every line
+// written to the underlying io.Writer. Each call to Write is passed through to
+// the underlying io.Writer in a single call, so that lines written by
+// concurrent prefixWriters sharing an io.Writer aren't interleaved.
+type prefixWriter struct {
+	mu          sync.Mutex
+	writer      io.Writer
+	prefix      string
+	atLineStart bool
+}
+
+func newPrefixWriter(writer io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{writer: writer, prefix: prefix, atLineStart: true}
+}
+
+func (w *prefixWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var buf bytes.Buffer
+	for _, b := range p {
+		if w.atLineStart {
+			buf.WriteString(w.prefix)
+			w.atLineStart = false
+		}
+		buf.WriteByte(b)
+		if b == '\n' {
+			w.atLineStart = true
+		}
+	}
+	if _, err := w.writer.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}